@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import "context"
+
+// ProjectsAPI manages Rollbar projects.
+type ProjectsAPI interface {
+	ListProjects(ctx context.Context) ([]Project, error)
+	CreateProject(ctx context.Context, name string) (*Project, error)
+	ReadProject(ctx context.Context, projectID int) (*Project, error)
+	DeleteProject(ctx context.Context, projectID int) error
+	FindProjectTeamIDs(ctx context.Context, projectID int) ([]int, error)
+	UpdateProjectTeams(ctx context.Context, projectID int, teamIDs []int) error
+}
+
+// ProjectAccessTokensAPI manages Rollbar project access tokens.
+type ProjectAccessTokensAPI interface {
+	ListProjectAccessTokens(ctx context.Context, projectID int) ([]ProjectAccessToken, error)
+	ReadProjectAccessToken(ctx context.Context, projectID int, token string) (ProjectAccessToken, error)
+	ReadProjectAccessTokenByName(ctx context.Context, projectID int, name string) (ProjectAccessToken, error)
+	CreateProjectAccessToken(ctx context.Context, args ProjectAccessTokenCreateArgs) (ProjectAccessToken, error)
+	UpdateProjectAccessToken(ctx context.Context, args ProjectAccessTokenUpdateArgs) error
+	DeleteProjectAccessToken(ctx context.Context, projectID int, token string) error
+}
+
+// TeamsAPI manages Rollbar teams and their user and project memberships.
+type TeamsAPI interface {
+	CreateTeam(ctx context.Context, name, level string) (Team, error)
+	ListTeams(ctx context.Context) ([]Team, error)
+	ListCustomTeams(ctx context.Context) ([]Team, error)
+	ReadTeam(ctx context.Context, id int) (Team, error)
+	DeleteTeam(ctx context.Context, id int) error
+	FindTeamID(ctx context.Context, name string) (int, error)
+	AssignUserToTeam(ctx context.Context, teamID, userID int) error
+	IsUserAssignedToTeam(ctx context.Context, teamID, userID int) (bool, error)
+	RemoveUserFromTeam(ctx context.Context, userID, teamID int) error
+	AssignTeamToProject(ctx context.Context, teamID, projectID int) error
+	RemoveTeamFromProject(ctx context.Context, teamID, projectID int) error
+}
+
+// UsersAPI reads Rollbar users.
+type UsersAPI interface {
+	ListUsers(ctx context.Context, email string) ([]User, error)
+	ReadUser(ctx context.Context, id int) (User, error)
+	FindUserID(ctx context.Context, email string) (int, error)
+	ListUserTeams(ctx context.Context, userID int) ([]Team, error)
+	ListUserCustomTeams(ctx context.Context, userID int) ([]Team, error)
+}
+
+// InvitationsAPI manages invitations for users to join Rollbar teams.
+type InvitationsAPI interface {
+	ListAllInvitationsPerEmail(ctx context.Context, email string) ([]Invitation, error)
+	ListInvitations(ctx context.Context, teamID int) ([]Invitation, error)
+	ListPendingInvitations(ctx context.Context, teamID int) ([]Invitation, error)
+	FindInvitations(ctx context.Context, email string) ([]Invitation, error)
+	FindPendingInvitations(ctx context.Context, email string) ([]Invitation, error)
+	CreateInvitation(ctx context.Context, teamID int, email string) (Invitation, error)
+	ReadInvitation(ctx context.Context, inviteID int) (Invitation, error)
+	DeleteInvitation(ctx context.Context, id int) error
+	CancelInvitation(ctx context.Context, id int) error
+}
+
+// NotificationsAPI manages Rollbar notification rules.
+type NotificationsAPI interface {
+	CreateNotification(ctx context.Context, channel string, filters, trigger, config interface{}, status string) (*Notification, error)
+	UpdateNotification(ctx context.Context, notificationID int, channel string, filters, trigger, config interface{}, status string) (*Notification, error)
+	ReadNotification(ctx context.Context, notificationID int, channel string) (*Notification, error)
+	DeleteNotification(ctx context.Context, notificationID int, channel string) error
+	ListNotifications(ctx context.Context, channel string) ([]Notification, error)
+}
+
+// IntegrationsAPI manages Rollbar notification integrations.
+type IntegrationsAPI interface {
+	UpdateIntegration(ctx context.Context, integration string, bodyMap map[string]interface{}) (interface{}, error)
+	ReadIntegration(ctx context.Context, integration string) (interface{}, error)
+}
+
+// ServiceLinksAPI manages Rollbar service links.
+type ServiceLinksAPI interface {
+	CreateServiceLink(ctx context.Context, name, template string) (*ServiceLink, error)
+	UpdateServiceLink(ctx context.Context, id int, name, template string) (*ServiceLink, error)
+	ReadServiceLink(ctx context.Context, id int) (*ServiceLink, error)
+	DeleteServiceLink(ctx context.Context, id int) error
+	ListSerivceLinks(ctx context.Context) ([]ServiceLink, error)
+}
+
+// RollbarAPI is the complete Rollbar API, as implemented by RollbarAPIClient
+// and by the in-memory fake in package clienttest.
+type RollbarAPI interface {
+	ProjectsAPI
+	ProjectAccessTokensAPI
+	TeamsAPI
+	UsersAPI
+	InvitationsAPI
+	NotificationsAPI
+	IntegrationsAPI
+	ServiceLinksAPI
+}
+
+var _ RollbarAPI = (*RollbarAPIClient)(nil)
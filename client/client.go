@@ -24,24 +24,25 @@
 package client
 
 import (
+	"context"
 	"net/http"
-	"sync"
+	"net/url"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // DefaultBaseURL is the default base URL for the Rollbar API.
 const DefaultBaseURL = "https://api.rollbar.com"
 const Version = "v1.15.1"
 
-// RollbarAPIClient is a client for the Rollbar API.
+// RollbarAPIClient is a client for the Rollbar API.  It is safe for concurrent
+// use by multiple goroutines.
 type RollbarAPIClient struct {
 	BaseURL string // Base URL for Rollbar API
 	Resty   *resty.Client
-
-	m sync.Mutex
 }
 
 // NewTestClient sets up a new Rollbar API test client.
@@ -76,6 +77,10 @@ func NewTestClient(baseURL, token string) *RollbarAPIClient {
 
 	// Configure Resty to use Zerolog for logging
 	r.SetLogger(restyZeroLogger{log.Logger})
+	r.OnRequestLog(redactRequestLog)
+	r.OnResponseLog(redactResponseLog)
+
+	r.OnBeforeRequest(setTrackingHeaders)
 
 	// Rollbar client
 	c := RollbarAPIClient{
@@ -85,42 +90,206 @@ func NewTestClient(baseURL, token string) *RollbarAPIClient {
 	return &c
 }
 
-func (c *RollbarAPIClient) SetHeaderResource(header string) {
-	c.m.Lock()
-	defer c.m.Unlock()
-	c.Resty.SetHeader("X-Rollbar-Terraform-Resource", header)
+// contextKey is the type of keys for values stored in a context.Context by
+// this package.
+type contextKey int
+
+const (
+	contextKeyResource contextKey = iota
+	contextKeyDataSource
+	contextKeyRetryNotFound
+	contextKeyRequestInfo
+)
+
+// WithResource returns a copy of ctx carrying the name of the Terraform
+// resource on whose behalf API requests are made.  It is sent to Rollbar in
+// header `X-Rollbar-Terraform-Resource`.
+func WithResource(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, contextKeyResource, name)
+}
+
+// WithDataSource returns a copy of ctx carrying the name of the Terraform data
+// source on whose behalf API requests are made.  It is sent to Rollbar in
+// header `X-Rollbar-Terraform-DataSource`.
+func WithDataSource(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, contextKeyDataSource, name)
+}
+
+// WithNotFoundRetry returns a copy of ctx under which '404 Not Found' responses
+// are retried, whether or not they are in Options.RetryableStatusCodes.  Use it
+// to read back an object immediately after creating it, when the API may not
+// yet return the new object.  Ordinary reads should not retry on 404, so that
+// missing objects are reported promptly.
+func WithNotFoundRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyRetryNotFound, true)
+}
+
+// setTrackingHeaders is a Resty request middleware that sets the Terraform
+// resource and data source headers from the request's context.  Headers are
+// set per request, so concurrent calls never see each other's values.
+func setTrackingHeaders(_ *resty.Client, req *resty.Request) error {
+	ctx := req.Context()
+	if name, ok := ctx.Value(contextKeyResource).(string); ok {
+		req.SetHeader("X-Rollbar-Terraform-Resource", name)
+	}
+	if name, ok := ctx.Value(contextKeyDataSource).(string); ok {
+		req.SetHeader("X-Rollbar-Terraform-DataSource", name)
+	}
+	return nil
+}
+
+// requestInfo is what the client's transports need to know about a request
+// that is only available to Resty: the path template before parameters are
+// substituted, and which attempt at the request this is.
+type requestInfo struct {
+	route   string
+	attempt int
+}
+
+// setRequestInfo is a Resty request middleware that records the request's
+// path template and attempt number in its context.  Resty runs it on every
+// attempt, before path parameters are substituted into req.URL.
+func setRequestInfo(_ *resty.Client, req *resty.Request) error {
+	info := &requestInfo{attempt: req.Attempt}
+	if u, err := url.Parse(req.URL); err == nil {
+		info.route = u.Path
+	}
+	req.SetContext(context.WithValue(req.Context(), contextKeyRequestInfo, info))
+	return nil
+}
+
+// rejectNoToken is a Resty request middleware for clients without an API
+// token that require one.  It refuses every request with a *MissingTokenError,
+// which names the Terraform resource or data source that needed the token.
+func rejectNoToken(_ *resty.Client, req *resty.Request) error {
+	e := &MissingTokenError{Method: req.Method, Path: req.URL}
+	if u, err := url.Parse(req.URL); err == nil {
+		e.Path = u.Path
+	}
+	ctx := req.Context()
+	e.Resource, _ = ctx.Value(contextKeyResource).(string)
+	e.DataSource, _ = ctx.Value(contextKeyDataSource).(string)
+	return e
+}
+
+// SetMaxConcurrentRequests limits the number of HTTP requests this client will
+// have in flight at once.  A value of zero or less means no limit.  Time spent
+// waiting between retries does not count against the limit.
+func (c *RollbarAPIClient) SetMaxConcurrentRequests(n int) {
+	if n <= 0 {
+		return
+	}
+	hc := c.Resty.GetClient()
+	hc.Transport = newLimitTransport(hc.Transport, n)
+}
+
+// Options configures the HTTP behavior of a RollbarAPIClient.
+type Options struct {
+	// Timeout is the time limit for each HTTP request.  Zero means no limit.
+	Timeout time.Duration
+
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries int
+
+	// MinBackoff and MaxBackoff bound the exponential backoff between
+	// retries.  Rate limited requests instead wait for the rate limit reset.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RetryableStatusCodes lists the HTTP response statuses that are retried.
+	// Network errors are always retried, and '404 Not Found' is retried for
+	// requests made with a context from WithNotFoundRetry.
+	RetryableStatusCodes []int
+
+	// MaxConcurrentRequests limits the number of requests in flight.  Zero
+	// means no limit.
+	MaxConcurrentRequests int
+
+	// TracerProvider, if not nil, is used to create an OpenTelemetry span for
+	// every HTTP request, including each retry.
+	TracerProvider trace.TracerProvider
+
+	// Stats, if not nil, counts the requests made, retries and time spent
+	// waiting.  It may be shared between clients.
+	Stats *Stats
+
+	// HAR, if not nil, records every request and response, with secrets
+	// masked.
+	HAR *HARRecorder
+
+	// ReadOnly makes the client refuse, with ErrReadOnly, every request that
+	// could change anything in Rollbar, i.e. anything but GET, HEAD and
+	// OPTIONS.  What would have been sent is logged instead.
+	ReadOnly bool
+
+	// RequireToken makes a client without an API token refuse every request
+	// with a *MissingTokenError, rather than send it unauthenticated.
+	RequireToken bool
+
+	// Transport, if not nil, sends the client's requests instead of
+	// http.DefaultTransport.  See NewTransport.
+	Transport http.RoundTripper
 }
 
-func (c *RollbarAPIClient) SetHeaderDataSource(header string) {
-	c.m.Lock()
-	defer c.m.Unlock()
-	c.Resty.SetHeader("X-Rollbar-Terraform-DataSource", header)
+// DefaultOptions returns the Options used by NewClient.
+func DefaultOptions() Options {
+	return Options{
+		Timeout:    30 * time.Second,
+		MaxRetries: 4,
+		MinBackoff: 8 * time.Second,
+		MaxBackoff: 50 * time.Second,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+		},
+	}
 }
 
-// NewClient sets up a new Rollbar API client.
+// NewClient sets up a new Rollbar API client with DefaultOptions.
 func NewClient(baseURL, token string) *RollbarAPIClient {
+	return NewClientWithOptions(baseURL, token, DefaultOptions())
+}
+
+// NewClientWithOptions sets up a new Rollbar API client.
+func NewClientWithOptions(baseURL, token string, opts Options) *RollbarAPIClient {
 	log.Debug().Msg("Initializing Rollbar client")
 	now := time.Now().Format(time.RFC3339Nano)
+	retryable := make(map[int]bool, len(opts.RetryableStatusCodes))
+	for _, code := range opts.RetryableStatusCodes {
+		retryable[code] = true
+	}
+
 	// New Resty HTTP client
 	r := resty.New()
 
-	// Use default transport - needed for VCR
-	r.SetTransport(http.DefaultTransport).
+	// Use default transport unless configured otherwise - needed for VCR
+	transport := opts.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r.SetTransport(transport).
 		// set timeout on http client
-		SetTimeout(30 * time.Second).
-		// Set retry count to 4 (try 5 times before it fails)
-		SetRetryCount(4).
-		SetRetryWaitTime(8 * time.Second).
-		SetRetryMaxWaitTime(50 * time.Second).
+		SetTimeout(opts.Timeout).
+		SetRetryCount(opts.MaxRetries).
+		// Wait times are computed by retryAfter; Resty's own bounds must not
+		// cut short a wait for the rate limit to reset.
+		SetRetryWaitTime(0).
+		SetRetryMaxWaitTime(maxRateLimitWait).
+		SetRetryAfter(retryAfter(opts.MinBackoff, opts.MaxBackoff, opts.Stats)).
 		AddRetryCondition(
 			func(r *resty.Response, err error) bool {
-				if err != nil { // network error
+				if err != nil {
+					// Without a response, a request middleware refused
+					// the request, e.g. in read-only mode or without a
+					// token, and retrying would only refuse it again.
+					// Otherwise it is a network error.
+					return r != nil
+				}
+				if r.StatusCode() == http.StatusNotFound && r.Request.Context().Value(contextKeyRetryNotFound) == true {
 					return true
 				}
-				return r.StatusCode() == http.StatusNotFound ||
-					r.StatusCode() == http.StatusTooManyRequests ||
-					r.StatusCode() == http.StatusInternalServerError ||
-					r.StatusCode() == http.StatusBadGateway
+				return retryable[r.StatusCode()]
 			})
 	// Authentication
 
@@ -132,6 +301,9 @@ func NewClient(baseURL, token string) *RollbarAPIClient {
 		})
 	} else {
 		log.Warn().Msg("Rollbar API token not set")
+		if opts.RequireToken {
+			r.OnBeforeRequest(rejectNoToken)
+		}
 	}
 
 	// Authentication
@@ -141,12 +313,43 @@ func NewClient(baseURL, token string) *RollbarAPIClient {
 
 	// Configure Resty to use Zerolog for logging
 	r.SetLogger(restyZeroLogger{log.Logger})
+	r.OnRequestLog(redactRequestLog)
+	r.OnResponseLog(redactResponseLog)
+
+	if opts.ReadOnly {
+		r.OnBeforeRequest(rejectMutating)
+	}
+	r.OnBeforeRequest(setTrackingHeaders)
+	r.OnBeforeRequest(setRequestInfo)
+
+	// Track the API rate limit quota and slow down before exhausting it
+	rl := &rateLimiter{stats: opts.Stats}
+	r.OnBeforeRequest(rl.beforeRequest)
+	r.OnAfterResponse(rl.afterResponse)
+
+	// Record each request as sent on the wire
+	if opts.HAR != nil {
+		hc := r.GetClient()
+		hc.Transport = newHARTransport(hc.Transport, opts.HAR)
+	}
+
+	// Trace each request, inside the concurrency limit so spans measure only
+	// the time spent talking to the API
+	if opts.TracerProvider != nil {
+		hc := r.GetClient()
+		hc.Transport = newTracingTransport(hc.Transport, opts.TracerProvider)
+	}
+	if opts.Stats != nil {
+		hc := r.GetClient()
+		hc.Transport = newStatsTransport(hc.Transport, opts.Stats)
+	}
 
 	// Rollbar client
 	c := RollbarAPIClient{
 		Resty:   r,
 		BaseURL: baseURL,
 	}
+	c.SetMaxConcurrentRequests(opts.MaxConcurrentRequests)
 	return &c
 }
 
@@ -160,22 +363,34 @@ const (
 )
 
 // errorFromResponse interprets the status code of Resty response, returning nil
-// on success or an appropriate error code
+// on success or an *APIError describing the failure.
 func errorFromResponse(resp *resty.Response) error {
 	switch resp.StatusCode() {
 	case http.StatusOK, http.StatusCreated:
 		return nil
-	case http.StatusUnauthorized:
-		return ErrUnauthorized
-	case http.StatusNotFound:
-		return ErrNotFound
-	default:
-		er := resp.Error().(*ErrorResult)
-		log.Error().
-			Int("StatusCode", resp.StatusCode()).
-			Str("Status", resp.Status()).
-			Interface("ErrorResult", er).
-			Send()
-		return er
 	}
+	ae := &APIError{
+		StatusCode: resp.StatusCode(),
+		Body:       RedactBody(string(resp.Body())),
+		RequestID:  resp.Header().Get("X-Request-Id"),
+	}
+	if resp.Request != nil {
+		ae.Method = resp.Request.Method
+		ae.URL = RedactURL(resp.Request.URL)
+	}
+	// Response body may not be a Rollbar error result, e.g. an HTML page from
+	// a proxy.
+	if er, ok := resp.Error().(*ErrorResult); ok && er != nil {
+		ae.Err = er.Err
+		ae.Message = er.Message
+	}
+	log.Error().
+		Int("StatusCode", ae.StatusCode).
+		Str("Status", resp.Status()).
+		Str("Method", ae.Method).
+		Str("URL", ae.URL).
+		Int("Err", ae.Err).
+		Str("Message", ae.Message).
+		Send()
+	return ae
 }
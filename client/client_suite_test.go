@@ -79,7 +79,7 @@ type Suite struct {
 func (s *Suite) SetupSuite() {
 	// Pretty logging
 	log.Logger = log.
-		Output(zerolog.ConsoleWriter{Out: os.Stderr}).
+		Output(NewRedactingWriter(zerolog.ConsoleWriter{Out: os.Stderr})).
 		With().Caller().
 		Logger()
 	zerolog.SetGlobalLevel(zerolog.DebugLevel)
@@ -115,14 +115,14 @@ func (s *Suite) checkServerErrors(mockMethod, mockUrl string, testFunc func() er
 		ErrorResult{Err: 404, Message: "Not Found"})
 	httpmock.RegisterResponder(mockMethod, mockUrl, r)
 	err := testFunc()
-	s.Equal(ErrNotFound, err)
+	s.ErrorIs(err, ErrNotFound)
 
 	// Unauthorized
 	r = httpmock.NewJsonResponderOrPanic(http.StatusUnauthorized,
 		ErrorResult{Err: 401, Message: "Unauthorized"})
 	httpmock.RegisterResponder(mockMethod, mockUrl, r)
 	err = testFunc()
-	s.Equal(ErrUnauthorized, err)
+	s.ErrorIs(err, ErrUnauthorized)
 
 	// Internal server error
 	r = httpmock.NewJsonResponderOrPanic(http.StatusInternalServerError,
@@ -130,7 +130,7 @@ func (s *Suite) checkServerErrors(mockMethod, mockUrl string, testFunc func() er
 	httpmock.RegisterResponder(mockMethod, mockUrl, r)
 	err = testFunc()
 	s.NotNil(err)
-	s.NotEqual(ErrNotFound, err)
+	s.NotErrorIs(err, ErrNotFound)
 
 	// Unreachable server
 	httpmock.Reset()
@@ -145,14 +145,14 @@ func (s *Suite) checkServerErrorsWithQuery(mockMethod, mockUrl string, expectedQ
 		ErrorResult{Err: 404, Message: "Not Found"})
 	httpmock.RegisterResponderWithQuery(mockMethod, mockUrl, expectedQuery, r)
 	err := testFunc()
-	s.Equal(ErrNotFound, err)
+	s.ErrorIs(err, ErrNotFound)
 
 	// Unauthorized
 	r = httpmock.NewJsonResponderOrPanic(http.StatusUnauthorized,
 		ErrorResult{Err: 401, Message: "Unauthorized"})
 	httpmock.RegisterResponderWithQuery(mockMethod, mockUrl, expectedQuery, r)
 	err = testFunc()
-	s.Equal(ErrUnauthorized, err)
+	s.ErrorIs(err, ErrUnauthorized)
 
 	// Internal server error
 	r = httpmock.NewJsonResponderOrPanic(http.StatusInternalServerError,
@@ -160,7 +160,7 @@ func (s *Suite) checkServerErrorsWithQuery(mockMethod, mockUrl string, expectedQ
 	httpmock.RegisterResponderWithQuery(mockMethod, mockUrl, expectedQuery, r)
 	err = testFunc()
 	s.NotNil(err)
-	s.NotEqual(ErrNotFound, err)
+	s.NotErrorIs(err, ErrNotFound)
 
 	// Unreachable server
 	httpmock.Reset()
@@ -24,9 +24,16 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"github.com/jarcoal/httpmock"
 	"github.com/rs/zerolog/log"
 	"io"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 // TestClientNoToken checks that a warning message is logged when a
@@ -53,3 +60,96 @@ func (s *Suite) TestClientNoBaseURL() {
 	s.Contains(bs, "error")
 	s.Contains(bs, "Rollbar API base URL not set")
 }
+
+// TestClientContextCanceled checks that canceling the context aborts an API
+// call that is waiting to retry, rather than sleeping through the backoff.
+func (s *Suite) TestClientContextCanceled() {
+	c := NewClient(DefaultBaseURL, "fakeTokenString")
+	httpmock.ActivateNonDefault(c.Resty.GetClient())
+	u := c.BaseURL + pathProjectList
+	r := httpmock.NewJsonResponderOrPanic(http.StatusInternalServerError,
+		ErrorResult{Err: 500, Message: "Internal Server Error"})
+	httpmock.RegisterResponder("GET", u, r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.ListProjects(ctx)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Less(time.Since(start), 5*time.Second)
+}
+
+// TestClientConcurrentTrackingHeaders checks that concurrent API calls each
+// send the Terraform resource header from their own context.
+func (s *Suite) TestClientConcurrentTrackingHeaders() {
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		projectID := i
+		resourceName := "resource-" + strconv.Itoa(projectID)
+		u := strings.ReplaceAll(s.client.BaseURL+pathProjectRead, "{projectID}", strconv.Itoa(projectID))
+		httpmock.RegisterResponder("GET", u, func(req *http.Request) (*http.Response, error) {
+			s.Equal(resourceName, req.Header.Get("X-Rollbar-Terraform-Resource"))
+			return httpmock.NewJsonResponse(http.StatusOK, projectResponse{
+				Result: Project{ID: projectID, Name: resourceName},
+			})
+		})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := WithResource(context.Background(), resourceName)
+			_, err := s.client.ReadProject(ctx, projectID)
+			s.Nil(err)
+		}()
+	}
+	wg.Wait()
+}
+
+// TestClientOptions checks that NewClientWithOptions retries exactly the
+// configured status codes, the configured number of times.
+func (s *Suite) TestClientOptions() {
+	status := http.StatusServiceUnavailable
+	srv, arrivals := s.rateLimitServer(func(n int, w http.ResponseWriter) {
+		w.WriteHeader(status)
+	})
+	opts := DefaultOptions()
+	opts.MaxRetries = 2
+	opts.MinBackoff = time.Millisecond
+	opts.MaxBackoff = 10 * time.Millisecond
+	opts.RetryableStatusCodes = []int{http.StatusServiceUnavailable}
+	c := NewClientWithOptions(srv.URL, "fakeTokenString", opts)
+
+	_, err := c.ReadProject(context.Background(), 1)
+	s.NotNil(err)
+	s.Len(arrivals(), 3)
+
+	// Status codes retried by default are not retried unless configured
+	status = http.StatusNotFound
+	_, err = c.ReadProject(context.Background(), 1)
+	s.ErrorIs(err, ErrNotFound)
+	s.Len(arrivals(), 4)
+}
+
+// TestClientNotFoundRetry checks that a 404 is returned at once by default,
+// but retried under a context from WithNotFoundRetry.
+func (s *Suite) TestClientNotFoundRetry() {
+	srv, arrivals := s.rateLimitServer(func(n int, w http.ResponseWriter) {
+		if n < 2 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeProject(w)
+	})
+	opts := DefaultOptions()
+	opts.MinBackoff = time.Millisecond
+	opts.MaxBackoff = 10 * time.Millisecond
+	c := NewClientWithOptions(srv.URL, "fakeTokenString", opts)
+
+	_, err := c.ReadProject(context.Background(), 1)
+	s.ErrorIs(err, ErrNotFound)
+	s.Len(arrivals(), 1)
+
+	project, err := c.ReadProject(WithNotFoundRetry(context.Background()), 1)
+	s.Nil(err)
+	s.Equal("foo", project.Name)
+	s.Len(arrivals(), 3)
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package clienttest
+
+import (
+	"os"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FakeAPIEnv is the environment variable that, set to "1", makes the
+// acceptance tests run hermetically against a Server.
+const FakeAPIEnv = "ROLLBAR_FAKE_API"
+
+// StartFakeAPI starts a Server if environment variable FakeAPIEnv is "1", and
+// points the provider at it: ROLLBAR_API_URL is set to the server's URL, and
+// ROLLBAR_API_KEY and ROLLBAR_PROJECT_API_KEY to fake tokens unless already
+// set.  It returns nil if FakeAPIEnv is not "1".  The caller should call Close
+// on the server when finished.
+func StartFakeAPI() (*Server, error) {
+	if os.Getenv(FakeAPIEnv) != "1" {
+		return nil, nil
+	}
+	srv := NewServer()
+	for k, v := range map[string]string{
+		"ROLLBAR_API_KEY":         "fake-api-key",
+		"ROLLBAR_PROJECT_API_KEY": "fake-project-api-key",
+	} {
+		if os.Getenv(k) != "" {
+			continue
+		}
+		if err := os.Setenv(k, v); err != nil {
+			srv.Close()
+			return nil, err
+		}
+	}
+	if err := os.Setenv("ROLLBAR_API_URL", srv.URL); err != nil {
+		srv.Close()
+		return nil, err
+	}
+	log.Info().
+		Str("url", srv.URL).
+		Msg("Running acceptance tests against fake Rollbar API")
+	return srv, nil
+}
@@ -0,0 +1,830 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package clienttest provides an in-memory fake of the Rollbar API, for
+// testing code that uses package client without making HTTP requests.
+package clienttest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rollbar/terraform-provider-rollbar/client"
+)
+
+// AccountID is the account to which all objects in a Fake belong.
+const AccountID = 1
+
+// Fake is an in-memory implementation of client.RollbarAPI.  It models a
+// single Rollbar account, which starts out with only the system teams
+// "Everyone" and "Owners".  It is safe for concurrent use.
+type Fake struct {
+	m      sync.Mutex
+	nextID int
+
+	projects      map[int]client.Project
+	projectTeams  map[int]map[int]bool // project ID -> team IDs
+	tokens        map[int][]client.ProjectAccessToken
+	teams         map[int]client.Team
+	teamUsers     map[int]map[int]bool // team ID -> user IDs
+	users         map[int]client.User
+	invitations   map[int]client.Invitation
+	notifications map[int]client.Notification
+	integrations  map[string]interface{}
+	serviceLinks  map[int]client.ServiceLink
+}
+
+var _ client.RollbarAPI = (*Fake)(nil)
+
+// NewFake returns a new Fake.
+func NewFake() *Fake {
+	f := &Fake{
+		nextID:        1,
+		projects:      make(map[int]client.Project),
+		projectTeams:  make(map[int]map[int]bool),
+		tokens:        make(map[int][]client.ProjectAccessToken),
+		teams:         make(map[int]client.Team),
+		teamUsers:     make(map[int]map[int]bool),
+		users:         make(map[int]client.User),
+		invitations:   make(map[int]client.Invitation),
+		notifications: make(map[int]client.Notification),
+		integrations:  make(map[string]interface{}),
+		serviceLinks:  make(map[int]client.ServiceLink),
+	}
+	f.addTeam("Everyone", "standard")
+	f.addTeam("Owners", "standard")
+	return f
+}
+
+// AddUser adds a registered user to the account.  Rollbar users cannot be
+// created through the API, so tests seed them with this method.
+func (f *Fake) AddUser(email, username string) client.User {
+	f.m.Lock()
+	defer f.m.Unlock()
+	u := client.User{ID: f.newID(), Email: email, Username: username}
+	f.users[u.ID] = u
+	return u
+}
+
+// newID returns a new, unique object ID.  Callers must hold f.m.
+func (f *Fake) newID() int {
+	id := f.nextID
+	f.nextID++
+	return id
+}
+
+// addTeam creates a team.  Callers must hold f.m.
+func (f *Fake) addTeam(name, level string) client.Team {
+	t := client.Team{ID: f.newID(), AccountID: AccountID, Name: name, AccessLevel: level}
+	f.teams[t.ID] = t
+	f.teamUsers[t.ID] = make(map[int]bool)
+	return t
+}
+
+// apiError returns an error like the one RollbarAPIClient returns for a
+// response with the given HTTP status.
+func apiError(status int, format string, a ...interface{}) error {
+	return &client.APIError{
+		StatusCode: status,
+		Err:        1,
+		Message:    fmt.Sprintf(format, a...),
+	}
+}
+
+func notFound(format string, a ...interface{}) error {
+	return apiError(http.StatusNotFound, format, a...)
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// newAccessToken returns a random access token.
+func newAccessToken() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+/*
+ * Projects
+ */
+
+// ListProjects implements client.ProjectsAPI.
+func (f *Fake) ListProjects(_ context.Context) ([]client.Project, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	projects := make([]client.Project, 0, len(f.projects))
+	for _, id := range sortedKeys(f.projects) {
+		projects = append(projects, f.projects[id])
+	}
+	return projects, nil
+}
+
+// CreateProject implements client.ProjectsAPI.  Like Rollbar, it gives the new
+// project four default access tokens.
+func (f *Fake) CreateProject(_ context.Context, name string) (*client.Project, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	for _, p := range f.projects {
+		if p.Name == name {
+			return nil, apiError(http.StatusUnprocessableEntity, "Project with this name already exists")
+		}
+	}
+	p := client.Project{ID: f.newID(), Name: name, AccountID: AccountID, Status: "enabled"}
+	f.projects[p.ID] = p
+	f.projectTeams[p.ID] = make(map[int]bool)
+	for _, n := range []string{"read", "write", "post_client_item", "post_server_item"} {
+		f.tokens[p.ID] = append(f.tokens[p.ID], client.ProjectAccessToken{
+			Name:        n,
+			ProjectID:   p.ID,
+			AccessToken: newAccessToken(),
+			Scopes:      []client.Scope{client.Scope(n)},
+			Status:      client.StatusEnabled,
+		})
+	}
+	return &p, nil
+}
+
+// ReadProject implements client.ProjectsAPI.
+func (f *Fake) ReadProject(_ context.Context, projectID int) (*client.Project, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	p, ok := f.projects[projectID]
+	if !ok {
+		return nil, notFound("Project not found")
+	}
+	return &p, nil
+}
+
+// DeleteProject implements client.ProjectsAPI.
+func (f *Fake) DeleteProject(_ context.Context, projectID int) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.projects[projectID]; !ok {
+		return notFound("Project not found")
+	}
+	delete(f.projects, projectID)
+	delete(f.projectTeams, projectID)
+	delete(f.tokens, projectID)
+	return nil
+}
+
+// FindProjectTeamIDs implements client.ProjectsAPI.
+func (f *Fake) FindProjectTeamIDs(_ context.Context, projectID int) ([]int, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	teams, ok := f.projectTeams[projectID]
+	if !ok {
+		return nil, notFound("Project not found")
+	}
+	return sortedKeys(teams), nil
+}
+
+// UpdateProjectTeams implements client.ProjectsAPI.
+func (f *Fake) UpdateProjectTeams(_ context.Context, projectID int, teamIDs []int) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.projects[projectID]; !ok {
+		return notFound("Project not found")
+	}
+	teams := make(map[int]bool)
+	for _, id := range teamIDs {
+		if _, ok := f.teams[id]; !ok {
+			return notFound("Team not found")
+		}
+		teams[id] = true
+	}
+	f.projectTeams[projectID] = teams
+	return nil
+}
+
+/*
+ * Project access tokens
+ */
+
+// ListProjectAccessTokens implements client.ProjectAccessTokensAPI.
+func (f *Fake) ListProjectAccessTokens(_ context.Context, projectID int) ([]client.ProjectAccessToken, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.projects[projectID]; !ok {
+		return nil, notFound("Project not found")
+	}
+	return append([]client.ProjectAccessToken{}, f.tokens[projectID]...), nil
+}
+
+// findToken returns the index of a project's access token, or -1 if there is
+// no such token.  Callers must hold f.m.
+func (f *Fake) findToken(projectID int, match func(client.ProjectAccessToken) bool) int {
+	for i, pat := range f.tokens[projectID] {
+		if match(pat) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReadProjectAccessToken implements client.ProjectAccessTokensAPI.
+func (f *Fake) ReadProjectAccessToken(_ context.Context, projectID int, token string) (client.ProjectAccessToken, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	i := f.findToken(projectID, func(pat client.ProjectAccessToken) bool { return pat.AccessToken == token })
+	if i < 0 {
+		return client.ProjectAccessToken{}, client.ErrNotFound
+	}
+	return f.tokens[projectID][i], nil
+}
+
+// ReadProjectAccessTokenByName implements client.ProjectAccessTokensAPI.
+func (f *Fake) ReadProjectAccessTokenByName(_ context.Context, projectID int, name string) (client.ProjectAccessToken, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	i := f.findToken(projectID, func(pat client.ProjectAccessToken) bool { return pat.Name == name })
+	if i < 0 {
+		return client.ProjectAccessToken{}, client.ErrNotFound
+	}
+	return f.tokens[projectID][i], nil
+}
+
+// CreateProjectAccessToken implements client.ProjectAccessTokensAPI.
+func (f *Fake) CreateProjectAccessToken(_ context.Context, args client.ProjectAccessTokenCreateArgs) (client.ProjectAccessToken, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.projects[args.ProjectID]; !ok {
+		return client.ProjectAccessToken{}, notFound("Project not found")
+	}
+	if args.Name == "" || len(args.Scopes) == 0 {
+		return client.ProjectAccessToken{}, apiError(http.StatusUnprocessableEntity, "Name and scopes are required")
+	}
+	status := args.Status
+	if status == "" {
+		status = client.StatusEnabled
+	}
+	pat := client.ProjectAccessToken{
+		Name:                 args.Name,
+		ProjectID:            args.ProjectID,
+		AccessToken:          newAccessToken(),
+		Scopes:               append([]client.Scope{}, args.Scopes...),
+		Status:               status,
+		RateLimitWindowSize:  args.RateLimitWindowSize,
+		RateLimitWindowCount: args.RateLimitWindowCount,
+	}
+	f.tokens[args.ProjectID] = append(f.tokens[args.ProjectID], pat)
+	return pat, nil
+}
+
+// UpdateProjectAccessToken implements client.ProjectAccessTokensAPI.
+func (f *Fake) UpdateProjectAccessToken(_ context.Context, args client.ProjectAccessTokenUpdateArgs) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	i := f.findToken(args.ProjectID, func(pat client.ProjectAccessToken) bool { return pat.AccessToken == args.AccessToken })
+	if i < 0 {
+		return notFound("Access token not found")
+	}
+	pat := &f.tokens[args.ProjectID][i]
+	if args.Name != "" {
+		pat.Name = args.Name
+	}
+	if len(args.Scopes) > 0 {
+		pat.Scopes = append([]client.Scope{}, args.Scopes...)
+	}
+	if args.Status != "" {
+		pat.Status = args.Status
+	}
+	pat.RateLimitWindowSize = args.RateLimitWindowSize
+	pat.RateLimitWindowCount = args.RateLimitWindowCount
+	return nil
+}
+
+// DeleteProjectAccessToken implements client.ProjectAccessTokensAPI.
+func (f *Fake) DeleteProjectAccessToken(_ context.Context, projectID int, token string) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	i := f.findToken(projectID, func(pat client.ProjectAccessToken) bool { return pat.AccessToken == token })
+	if i < 0 {
+		return notFound("Access token not found")
+	}
+	pats := f.tokens[projectID]
+	f.tokens[projectID] = append(pats[:i:i], pats[i+1:]...)
+	return nil
+}
+
+/*
+ * Teams
+ */
+
+// CreateTeam implements client.TeamsAPI.
+func (f *Fake) CreateTeam(_ context.Context, name, level string) (client.Team, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	switch level {
+	case "standard", "light", "view":
+	default:
+		return client.Team{}, apiError(http.StatusUnprocessableEntity, "Invalid access level: %s", level)
+	}
+	return f.addTeam(name, level), nil
+}
+
+// ListTeams implements client.TeamsAPI.
+func (f *Fake) ListTeams(_ context.Context) ([]client.Team, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	teams := make([]client.Team, 0, len(f.teams))
+	for _, id := range sortedKeys(f.teams) {
+		teams = append(teams, f.teams[id])
+	}
+	return teams, nil
+}
+
+// ListCustomTeams implements client.TeamsAPI.
+func (f *Fake) ListCustomTeams(ctx context.Context) ([]client.Team, error) {
+	teams, err := f.ListTeams(ctx)
+	return customTeams(teams), err
+}
+
+// customTeams filters out the system teams "Everyone" and "Owners".
+func customTeams(teams []client.Team) []client.Team {
+	custom := []client.Team{}
+	for _, t := range teams {
+		if t.Name != "Everyone" && t.Name != "Owners" {
+			custom = append(custom, t)
+		}
+	}
+	return custom
+}
+
+// ReadTeam implements client.TeamsAPI.
+func (f *Fake) ReadTeam(_ context.Context, id int) (client.Team, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	t, ok := f.teams[id]
+	if !ok {
+		return client.Team{}, notFound("Team not found")
+	}
+	return t, nil
+}
+
+// DeleteTeam implements client.TeamsAPI.
+func (f *Fake) DeleteTeam(_ context.Context, id int) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.teams[id]; !ok {
+		return notFound("Team not found")
+	}
+	delete(f.teams, id)
+	delete(f.teamUsers, id)
+	for _, teams := range f.projectTeams {
+		delete(teams, id)
+	}
+	return nil
+}
+
+// FindTeamID implements client.TeamsAPI.
+func (f *Fake) FindTeamID(_ context.Context, name string) (int, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	for _, id := range sortedKeys(f.teams) {
+		if f.teams[id].Name == name {
+			return id, nil
+		}
+	}
+	return 0, client.ErrNotFound
+}
+
+// AssignUserToTeam implements client.TeamsAPI.
+func (f *Fake) AssignUserToTeam(_ context.Context, teamID, userID int) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.teams[teamID]; !ok {
+		return notFound("Team not found")
+	}
+	if _, ok := f.users[userID]; !ok {
+		return notFound("User not found")
+	}
+	f.teamUsers[teamID][userID] = true
+	return nil
+}
+
+// IsUserAssignedToTeam implements client.TeamsAPI.
+func (f *Fake) IsUserAssignedToTeam(_ context.Context, teamID, userID int) (bool, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return f.teamUsers[teamID][userID], nil
+}
+
+// RemoveUserFromTeam implements client.TeamsAPI.
+func (f *Fake) RemoveUserFromTeam(_ context.Context, userID, teamID int) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if !f.teamUsers[teamID][userID] {
+		return notFound("User is not a member of the team")
+	}
+	delete(f.teamUsers[teamID], userID)
+	return nil
+}
+
+// AssignTeamToProject implements client.TeamsAPI.
+func (f *Fake) AssignTeamToProject(_ context.Context, teamID, projectID int) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.teams[teamID]; !ok {
+		return notFound("Team not found")
+	}
+	if _, ok := f.projects[projectID]; !ok {
+		return notFound("Project not found")
+	}
+	f.projectTeams[projectID][teamID] = true
+	return nil
+}
+
+// RemoveTeamFromProject implements client.TeamsAPI.
+func (f *Fake) RemoveTeamFromProject(_ context.Context, teamID, projectID int) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if !f.projectTeams[projectID][teamID] {
+		return notFound("Team is not assigned to the project")
+	}
+	delete(f.projectTeams[projectID], teamID)
+	return nil
+}
+
+/*
+ * Users
+ */
+
+// ListUsers implements client.UsersAPI.
+func (f *Fake) ListUsers(_ context.Context, email string) ([]client.User, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	var users []client.User
+	for _, id := range sortedKeys(f.users) {
+		u := f.users[id]
+		if email == "" || u.Email == email {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+// ReadUser implements client.UsersAPI.
+func (f *Fake) ReadUser(_ context.Context, id int) (client.User, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	u, ok := f.users[id]
+	if !ok {
+		return client.User{}, notFound("User not found")
+	}
+	return u, nil
+}
+
+// FindUserID implements client.UsersAPI.
+func (f *Fake) FindUserID(ctx context.Context, email string) (int, error) {
+	users, err := f.ListUsers(ctx, email)
+	if err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, client.ErrNotFound
+	}
+	return users[0].ID, nil
+}
+
+// ListUserTeams implements client.UsersAPI.
+func (f *Fake) ListUserTeams(_ context.Context, userID int) ([]client.Team, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.users[userID]; !ok {
+		return nil, notFound("User not found")
+	}
+	var teams []client.Team
+	for _, id := range sortedKeys(f.teams) {
+		if f.teamUsers[id][userID] {
+			teams = append(teams, f.teams[id])
+		}
+	}
+	return teams, nil
+}
+
+// ListUserCustomTeams implements client.UsersAPI.
+func (f *Fake) ListUserCustomTeams(ctx context.Context, userID int) ([]client.Team, error) {
+	teams, err := f.ListUserTeams(ctx, userID)
+	return customTeams(teams), err
+}
+
+/*
+ * Invitations
+ */
+
+// listInvitations returns the invitations matching filter.
+func (f *Fake) listInvitations(filter func(client.Invitation) bool) []client.Invitation {
+	f.m.Lock()
+	defer f.m.Unlock()
+	var invs []client.Invitation
+	for _, id := range sortedKeys(f.invitations) {
+		if inv := f.invitations[id]; filter(inv) {
+			invs = append(invs, inv)
+		}
+	}
+	return invs
+}
+
+// ListAllInvitationsPerEmail implements client.InvitationsAPI.
+func (f *Fake) ListAllInvitationsPerEmail(_ context.Context, email string) ([]client.Invitation, error) {
+	email = strings.ToLower(email)
+	return f.listInvitations(func(inv client.Invitation) bool {
+		return inv.ToEmail == email
+	}), nil
+}
+
+// ListInvitations implements client.InvitationsAPI.
+func (f *Fake) ListInvitations(_ context.Context, teamID int) ([]client.Invitation, error) {
+	return f.listInvitations(func(inv client.Invitation) bool {
+		return inv.TeamID == teamID
+	}), nil
+}
+
+// ListPendingInvitations implements client.InvitationsAPI.
+func (f *Fake) ListPendingInvitations(_ context.Context, teamID int) ([]client.Invitation, error) {
+	return f.listInvitations(func(inv client.Invitation) bool {
+		return inv.TeamID == teamID && inv.Status == "pending"
+	}), nil
+}
+
+// FindInvitations implements client.InvitationsAPI.
+func (f *Fake) FindInvitations(ctx context.Context, email string) ([]client.Invitation, error) {
+	invs, _ := f.ListAllInvitationsPerEmail(ctx, email)
+	if len(invs) == 0 {
+		return invs, client.ErrNotFound
+	}
+	return invs, nil
+}
+
+// FindPendingInvitations implements client.InvitationsAPI.
+func (f *Fake) FindPendingInvitations(ctx context.Context, email string) ([]client.Invitation, error) {
+	all, err := f.FindInvitations(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	var pending []client.Invitation
+	for _, inv := range all {
+		if inv.Status == "pending" {
+			pending = append(pending, inv)
+		}
+	}
+	return pending, nil
+}
+
+// CreateInvitation implements client.InvitationsAPI.
+func (f *Fake) CreateInvitation(_ context.Context, teamID int, email string) (client.Invitation, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.teams[teamID]; !ok {
+		return client.Invitation{}, notFound("Team not found")
+	}
+	inv := client.Invitation{
+		ID:      f.newID(),
+		TeamID:  teamID,
+		ToEmail: strings.ToLower(email),
+		Status:  "pending",
+	}
+	f.invitations[inv.ID] = inv
+	return inv, nil
+}
+
+// ReadInvitation implements client.InvitationsAPI.
+func (f *Fake) ReadInvitation(_ context.Context, inviteID int) (client.Invitation, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	inv, ok := f.invitations[inviteID]
+	if !ok {
+		return client.Invitation{}, notFound("Invite not found")
+	}
+	return inv, nil
+}
+
+// DeleteInvitation implements client.InvitationsAPI.
+func (f *Fake) DeleteInvitation(ctx context.Context, id int) error {
+	return f.CancelInvitation(ctx, id)
+}
+
+// CancelInvitation implements client.InvitationsAPI.  Canceling an invitation
+// that is already canceled succeeds.
+func (f *Fake) CancelInvitation(_ context.Context, id int) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	inv, ok := f.invitations[id]
+	if !ok {
+		return notFound("Invite not found")
+	}
+	inv.Status = "canceled"
+	f.invitations[id] = inv
+	return nil
+}
+
+/*
+ * Notifications
+ */
+
+// notification builds a notification rule from the arguments accepted by
+// CreateNotification and UpdateNotification.
+func notification(id int, channel string, filters, trigger, config interface{}, status string) client.Notification {
+	n := client.Notification{ID: id, Channel: channel, Status: status, Action: "send_" + channel}
+	n.Trigger, _ = trigger.(string)
+	n.Filters, _ = filters.([]interface{})
+	n.Config, _ = config.(map[string]interface{})
+	return n
+}
+
+// CreateNotification implements client.NotificationsAPI.
+func (f *Fake) CreateNotification(_ context.Context, channel string, filters, trigger, config interface{}, status string) (*client.Notification, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	n := notification(f.newID(), channel, filters, trigger, config, status)
+	f.notifications[n.ID] = n
+	return &n, nil
+}
+
+// UpdateNotification implements client.NotificationsAPI.
+func (f *Fake) UpdateNotification(_ context.Context, notificationID int, channel string, filters, trigger, config interface{}, status string) (*client.Notification, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if old, ok := f.notifications[notificationID]; !ok || old.Channel != channel {
+		return nil, notFound("Notification rule not found")
+	}
+	n := notification(notificationID, channel, filters, trigger, config, status)
+	f.notifications[n.ID] = n
+	return &n, nil
+}
+
+// ReadNotification implements client.NotificationsAPI.
+func (f *Fake) ReadNotification(_ context.Context, notificationID int, channel string) (*client.Notification, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	n, ok := f.notifications[notificationID]
+	if !ok || n.Channel != channel {
+		return nil, client.ErrNotFound
+	}
+	return &n, nil
+}
+
+// DeleteNotification implements client.NotificationsAPI.
+func (f *Fake) DeleteNotification(_ context.Context, notificationID int, channel string) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if n, ok := f.notifications[notificationID]; !ok || n.Channel != channel {
+		return notFound("Notification rule not found")
+	}
+	delete(f.notifications, notificationID)
+	return nil
+}
+
+// ListNotifications implements client.NotificationsAPI.
+func (f *Fake) ListNotifications(_ context.Context, channel string) ([]client.Notification, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	var ns []client.Notification
+	for _, id := range sortedKeys(f.notifications) {
+		if n := f.notifications[id]; n.Channel == channel {
+			ns = append(ns, n)
+		}
+	}
+	return ns, nil
+}
+
+/*
+ * Integrations
+ */
+
+// UpdateIntegration implements client.IntegrationsAPI.
+func (f *Fake) UpdateIntegration(_ context.Context, integration string, bodyMap map[string]interface{}) (interface{}, error) {
+	var i interface{}
+	switch integration {
+	case client.EMAIL:
+		i = &client.EmailIntegration{}
+	case client.PAGERDUTY:
+		i = &client.PagerDutyIntegration{}
+	case client.SLACK:
+		i = &client.SlackIntegration{}
+	case client.WEBHOOK:
+		i = &client.WebhookIntegration{}
+	default:
+		return nil, notFound("Unknown integration: %s", integration)
+	}
+	// Decode the request body into the integration's settings, as the API does
+	b, err := json.Marshal(map[string]interface{}{"settings": bodyMap})
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, i)
+	if err != nil {
+		return nil, err
+	}
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.integrations[integration] = i
+	return i, nil
+}
+
+// ReadIntegration implements client.IntegrationsAPI.
+func (f *Fake) ReadIntegration(_ context.Context, integration string) (interface{}, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	i, ok := f.integrations[integration]
+	if !ok {
+		return nil, client.ErrNotFound
+	}
+	return i, nil
+}
+
+/*
+ * Service links
+ */
+
+// CreateServiceLink implements client.ServiceLinksAPI.
+func (f *Fake) CreateServiceLink(_ context.Context, name, template string) (*client.ServiceLink, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	sl := client.ServiceLink{ID: f.newID(), Name: name, Template: template}
+	f.serviceLinks[sl.ID] = sl
+	return &sl, nil
+}
+
+// UpdateServiceLink implements client.ServiceLinksAPI.
+func (f *Fake) UpdateServiceLink(_ context.Context, id int, name, template string) (*client.ServiceLink, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.serviceLinks[id]; !ok {
+		return nil, notFound("Service link not found")
+	}
+	sl := client.ServiceLink{ID: id, Name: name, Template: template}
+	f.serviceLinks[id] = sl
+	return &sl, nil
+}
+
+// ReadServiceLink implements client.ServiceLinksAPI.
+func (f *Fake) ReadServiceLink(_ context.Context, id int) (*client.ServiceLink, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	sl, ok := f.serviceLinks[id]
+	if !ok {
+		return nil, client.ErrNotFound
+	}
+	return &sl, nil
+}
+
+// DeleteServiceLink implements client.ServiceLinksAPI.
+func (f *Fake) DeleteServiceLink(_ context.Context, id int) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if _, ok := f.serviceLinks[id]; !ok {
+		return notFound("Service link not found")
+	}
+	delete(f.serviceLinks, id)
+	return nil
+}
+
+// ListSerivceLinks implements client.ServiceLinksAPI.
+func (f *Fake) ListSerivceLinks(_ context.Context) ([]client.ServiceLink, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	links := make([]client.ServiceLink, 0, len(f.serviceLinks))
+	for _, id := range sortedKeys(f.serviceLinks) {
+		links = append(links, f.serviceLinks[id])
+	}
+	return links, nil
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package clienttest
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+)
+
+// HARResponder returns an httpmock.Responder that replays the responses
+// recorded in har, such as a HAR file captured by a provider user.  Each
+// request is answered with the first entry not yet replayed that has the same
+// method and, once secrets are masked, the same URL.  Register it with
+// httpmock.RegisterNoResponder to replay a whole archive.  Secrets are masked
+// in the replayed responses too, so e.g. listed access tokens read as
+// client.Redacted followed by a suffix that differs between tokens.
+func HARResponder(har *client.HAR) httpmock.Responder {
+	var m sync.Mutex
+	used := make([]bool, len(har.Log.Entries))
+	return func(req *http.Request) (*http.Response, error) {
+		m.Lock()
+		defer m.Unlock()
+		u := client.RedactURL(req.URL.String())
+		for i, e := range har.Log.Entries {
+			if used[i] || e.Request.Method != req.Method || e.Request.URL != u {
+				continue
+			}
+			used[i] = true
+			if e.Error != "" {
+				return nil, fmt.Errorf("recorded error: %s", e.Error)
+			}
+			resp := httpmock.NewStringResponse(e.Response.Status, e.Response.Content.Text)
+			for _, h := range e.Response.Headers {
+				resp.Header.Add(h.Name, h.Value)
+			}
+			resp.Request = req
+			return resp, nil
+		}
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, u)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package clienttest
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHARRecordReplay checks that API calls recorded in a HAR file against
+// the fake server can be replayed with HARResponder, and that the file holds
+// no access tokens.
+func TestHARRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+	path := filepath.Join(t.TempDir(), "rollbar.har")
+	rec, err := client.NewHARRecorder(path)
+	require.NoError(t, err)
+	opts := client.DefaultOptions()
+	opts.HAR = rec
+	c := client.NewClientWithOptions(srv.URL, "secretAccountToken", opts)
+
+	p, err := c.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	pats, err := c.ListProjectAccessTokens(ctx, p.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, pats)
+	read, err := c.ReadProject(ctx, p.ID)
+	require.NoError(t, err)
+
+	har, err := client.ReadHAR(path)
+	require.NoError(t, err)
+	require.Len(t, har.Log.Entries, 4)
+	create := har.Log.Entries[0]
+	assert.Equal(t, http.MethodPost, create.Request.Method)
+	assert.JSONEq(t, `{"name":"foo"}`, create.Request.PostData.Text)
+	assert.Equal(t, http.StatusOK, create.Response.Status)
+	assert.Contains(t, create.Request.Headers,
+		client.HARNameValue{Name: "X-Rollbar-Access-Token", Value: client.Redacted})
+	for _, e := range har.Log.Entries {
+		assert.NotContains(t, e.Request.URL, pats[0].AccessToken)
+		assert.NotContains(t, e.Response.Content.Text, pats[0].AccessToken)
+	}
+
+	mt := httpmock.NewMockTransport()
+	mt.RegisterNoResponder(HARResponder(har))
+	replay := client.NewTestClient(srv.URL, "anotherToken")
+	replay.Resty.GetClient().Transport = mt
+
+	replayed, err := replay.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, p, replayed)
+	replayedPATs, err := replay.ListProjectAccessTokens(ctx, p.ID)
+	require.NoError(t, err)
+	require.Len(t, replayedPATs, len(pats))
+	for i, pat := range replayedPATs {
+		assert.True(t, strings.HasPrefix(pat.AccessToken, client.Redacted))
+		assert.NotEqual(t, pats[i].AccessToken, pat.AccessToken)
+		assert.Equal(t, pats[i].Name, pat.Name)
+	}
+	replayedRead, err := replay.ReadProject(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, read, replayedRead)
+
+	_, err = replay.ReadProject(ctx, p.ID)
+	assert.Error(t, err, "each recorded response is replayed once")
+}
@@ -0,0 +1,875 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package clienttest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rollbar/terraform-provider-rollbar/client"
+)
+
+// defaultPerPage is the page size used when a request does not ask for one.
+const defaultPerPage = 20
+
+// Server is a fake Rollbar API server backed by a Fake.  It serves the
+// /api/1/* endpoints used by package client over HTTP, so code that talks to
+// the real API - such as the Terraform acceptance tests - can run against it
+// without network access.  Point the client or the provider's api_url at
+// Server.URL.
+//
+// Server reproduces known quirks of the Rollbar API:
+//
+//   - Deleted projects are still listed, and can still be read, but with an
+//     empty name.
+//   - Assigning a user to a team that does not exist, or to which the user
+//     cannot be assigned, returns '403 Forbidden'.
+//   - Removing a user from a team they are not on returns '422 Unprocessable
+//     Entity'.
+//   - Canceling an invitation that is already canceled returns '422
+//     Unprocessable Entity' with message "Invite already canceled".
+//   - Invited emails are converted to lowercase.
+type Server struct {
+	*httptest.Server
+
+	// Fake holds the server's state.  Tests may use it to seed objects that
+	// cannot be created through the API, such as users, and to inspect state.
+	Fake *Fake
+
+	m       sync.Mutex
+	deleted map[int]client.Project // Deleted projects, which the API still lists
+	routes  []route
+}
+
+// route maps a method and path pattern to a handler.  Pattern segments of the
+// form "{name}" match any single path segment, which is passed to the handler
+// as a path parameter.
+type route struct {
+	method  string
+	pattern string
+	handle  func(w http.ResponseWriter, r *http.Request, p params)
+}
+
+// params holds the path parameters of a request.
+type params map[string]string
+
+// NewServer starts and returns a new Server.  The caller should call Close
+// when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		Fake:    NewFake(),
+		deleted: make(map[int]client.Project),
+	}
+	s.routes = []route{
+		{http.MethodGet, "/api/1/projects", s.listProjects},
+		{http.MethodPost, "/api/1/projects", s.createProject},
+		{http.MethodGet, "/api/1/project/{projectID}", s.readProject},
+		{http.MethodDelete, "/api/1/project/{projectID}", s.deleteProject},
+		{http.MethodGet, "/api/1/project/{projectID}/teams", s.listProjectTeams},
+		{http.MethodGet, "/api/1/project/{projectID}/access_tokens", s.listProjectAccessTokens},
+		{http.MethodPost, "/api/1/project/{projectID}/access_tokens", s.createProjectAccessToken},
+		{http.MethodPatch, "/api/1/project/{projectID}/access_token/{accessToken}", s.updateProjectAccessToken},
+		{http.MethodDelete, "/api/1/project/{projectID}/access_token/{accessToken}", s.deleteProjectAccessToken},
+		{http.MethodGet, "/api/1/teams", s.listTeams},
+		{http.MethodPost, "/api/1/teams", s.createTeam},
+		{http.MethodGet, "/api/1/team/{teamID}", s.readTeam},
+		{http.MethodDelete, "/api/1/team/{teamID}", s.deleteTeam},
+		{http.MethodGet, "/api/1/team/{teamID}/user/{userID}", s.readTeamUser},
+		{http.MethodPut, "/api/1/team/{teamID}/user/{userID}", s.assignTeamUser},
+		{http.MethodDelete, "/api/1/team/{teamID}/user/{userID}", s.removeTeamUser},
+		{http.MethodPut, "/api/1/team/{teamID}/project/{projectID}", s.assignTeamProject},
+		{http.MethodDelete, "/api/1/team/{teamID}/project/{projectID}", s.removeTeamProject},
+		{http.MethodGet, "/api/1/team/{teamID}/invites", s.listTeamInvitations},
+		{http.MethodPost, "/api/1/team/{teamID}/invites", s.createInvitation},
+		{http.MethodGet, "/api/1/users", s.listUsers},
+		{http.MethodGet, "/api/1/user/{userID}", s.readUser},
+		{http.MethodGet, "/api/1/user/{userID}/teams", s.listUserTeams},
+		{http.MethodGet, "/api/1/invites", s.listInvitations},
+		{http.MethodGet, "/api/1/invite/{inviteID}", s.readInvitation},
+		{http.MethodDelete, "/api/1/invite/{inviteID}", s.cancelInvitation},
+		{http.MethodGet, "/api/1/notifications/{channel}/rules", s.listNotifications},
+		{http.MethodPost, "/api/1/notifications/{channel}/rules", s.createNotification},
+		{http.MethodGet, "/api/1/notifications/{channel}/rule/{notificationID}", s.readNotification},
+		{http.MethodPut, "/api/1/notifications/{channel}/rule/{notificationID}", s.updateNotification},
+		{http.MethodDelete, "/api/1/notifications/{channel}/rule/{notificationID}", s.deleteNotification},
+		{http.MethodGet, "/api/1/notifications/{integration}", s.readIntegration},
+		{http.MethodPut, "/api/1/notifications/{integration}", s.updateIntegration},
+		{http.MethodGet, "/api/1/service_links", s.listServiceLinks},
+		{http.MethodPost, "/api/1/service_links", s.createServiceLink},
+		{http.MethodGet, "/api/1/service_links/{id}", s.readServiceLink},
+		{http.MethodPut, "/api/1/service_links/{id}", s.updateServiceLink},
+		{http.MethodDelete, "/api/1/service_links/{id}", s.deleteServiceLink},
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Rollbar-Access-Token") == "" {
+		writeError(w, apiError(http.StatusUnauthorized, "Invalid access token"))
+		return
+	}
+	pathFound := false
+	for _, rt := range s.routes {
+		p, ok := match(rt.pattern, r.URL.Path)
+		if !ok {
+			continue
+		}
+		pathFound = true
+		if rt.method == r.Method {
+			rt.handle(w, r, p)
+			return
+		}
+	}
+	if pathFound {
+		writeError(w, apiError(http.StatusMethodNotAllowed, "Method not allowed"))
+		return
+	}
+	writeError(w, notFound("Not found"))
+}
+
+// match matches path against a route pattern, returning the path parameters.
+func match(pattern, path string) (params, bool) {
+	want := strings.Split(strings.Trim(pattern, "/"), "/")
+	got := strings.Split(strings.Trim(path, "/"), "/")
+	if len(want) != len(got) {
+		return nil, false
+	}
+	p := make(params)
+	for i, seg := range want {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			p[strings.Trim(seg, "{}")] = got[i]
+			continue
+		}
+		if seg != got[i] {
+			return nil, false
+		}
+	}
+	return p, true
+}
+
+// intParam returns the integer value of path parameter name.  If it is not an
+// integer, intParam writes a '404 Not Found' response and reports false.
+func intParam(w http.ResponseWriter, p params, name string) (int, bool) {
+	v, err := strconv.Atoi(p[name])
+	if err != nil {
+		writeError(w, notFound("Not found"))
+		return 0, false
+	}
+	return v, true
+}
+
+// decodeBody decodes the JSON request body into v.  If it cannot be decoded,
+// decodeBody writes a '400 Bad Request' response and reports false.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		writeError(w, apiError(http.StatusBadRequest, "Invalid JSON body: %s", err))
+		return false
+	}
+	return true
+}
+
+// paginate returns the page of items requested by the page and per_page query
+// parameters.
+func paginate[T any](r *http.Request, items []T) []T {
+	q := r.URL.Query()
+	page, err := strconv.Atoi(q.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(q.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	start := (page - 1) * perPage
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+// writeJSON writes v as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeResult writes a successful API response wrapping result.
+func writeResult(w http.ResponseWriter, result interface{}) {
+	writeJSON(w, http.StatusOK, struct {
+		Err    int         `json:"err"`
+		Result interface{} `json:"result"`
+	}{0, result})
+}
+
+// writeError writes an API error response for err.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	msg := err.Error()
+	var apiErr *client.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		status = apiErr.StatusCode
+		msg = apiErr.Message
+	case errors.Is(err, client.ErrNotFound):
+		status = http.StatusNotFound
+		msg = "Not found"
+	}
+	writeJSON(w, status, struct {
+		Err     int    `json:"err"`
+		Message string `json:"message"`
+	}{1, msg})
+}
+
+/*
+ * Projects
+ */
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, _ params) {
+	projects, err := s.Fake.ListProjects(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.m.Lock()
+	for _, p := range s.deleted {
+		projects = append(projects, p)
+	}
+	s.m.Unlock()
+	sort.Slice(projects, func(i, j int) bool { return projects[i].ID < projects[j].ID })
+	writeResult(w, paginate(r, projects))
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, _ params) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	p, err := s.Fake.CreateProject(r.Context(), body.Name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, p)
+}
+
+func (s *Server) readProject(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "projectID")
+	if !ok {
+		return
+	}
+	s.m.Lock()
+	deleted, isDeleted := s.deleted[id]
+	s.m.Unlock()
+	if isDeleted {
+		writeResult(w, deleted)
+		return
+	}
+	proj, err := s.Fake.ReadProject(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, proj)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "projectID")
+	if !ok {
+		return
+	}
+	proj, err := s.Fake.ReadProject(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	err = s.Fake.DeleteProject(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// The API keeps deleted projects around, with their name set to null
+	proj.Name = ""
+	s.m.Lock()
+	s.deleted[id] = *proj
+	s.m.Unlock()
+	writeResult(w, nil)
+}
+
+func (s *Server) listProjectTeams(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "projectID")
+	if !ok {
+		return
+	}
+	teamIDs, err := s.Fake.FindProjectTeamIDs(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	type teamProject struct {
+		ProjectID int `json:"project_id"`
+		TeamID    int `json:"team_id"`
+	}
+	result := make([]teamProject, 0, len(teamIDs))
+	for _, teamID := range teamIDs {
+		result = append(result, teamProject{ProjectID: id, TeamID: teamID})
+	}
+	writeResult(w, result)
+}
+
+/*
+ * Project access tokens
+ */
+
+func (s *Server) listProjectAccessTokens(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "projectID")
+	if !ok {
+		return
+	}
+	pats, err := s.Fake.ListProjectAccessTokens(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, paginate(r, pats))
+}
+
+func (s *Server) createProjectAccessToken(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "projectID")
+	if !ok {
+		return
+	}
+	var args client.ProjectAccessTokenCreateArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+	args.ProjectID = id
+	pat, err := s.Fake.CreateProjectAccessToken(r.Context(), args)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, pat)
+}
+
+func (s *Server) updateProjectAccessToken(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "projectID")
+	if !ok {
+		return
+	}
+	var args client.ProjectAccessTokenUpdateArgs
+	if !decodeBody(w, r, &args) {
+		return
+	}
+	args.ProjectID = id
+	args.AccessToken = p["accessToken"]
+	err := s.Fake.UpdateProjectAccessToken(r.Context(), args)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, nil)
+}
+
+func (s *Server) deleteProjectAccessToken(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "projectID")
+	if !ok {
+		return
+	}
+	err := s.Fake.DeleteProjectAccessToken(r.Context(), id, p["accessToken"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, nil)
+}
+
+/*
+ * Teams
+ */
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request, _ params) {
+	teams, err := s.Fake.ListTeams(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, paginate(r, teams))
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request, _ params) {
+	var body struct {
+		Name        string `json:"name"`
+		AccessLevel string `json:"access_level"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	t, err := s.Fake.CreateTeam(r.Context(), body.Name, body.AccessLevel)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, t)
+}
+
+func (s *Server) readTeam(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "teamID")
+	if !ok {
+		return
+	}
+	t, err := s.Fake.ReadTeam(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, t)
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "teamID")
+	if !ok {
+		return
+	}
+	err := s.Fake.DeleteTeam(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, nil)
+}
+
+// teamAndID returns the team ID and the ID in path parameter name.
+func teamAndID(w http.ResponseWriter, p params, name string) (int, int, bool) {
+	teamID, ok := intParam(w, p, "teamID")
+	if !ok {
+		return 0, 0, false
+	}
+	id, ok := intParam(w, p, name)
+	return teamID, id, ok
+}
+
+func (s *Server) readTeamUser(w http.ResponseWriter, r *http.Request, p params) {
+	teamID, userID, ok := teamAndID(w, p, "userID")
+	if !ok {
+		return
+	}
+	assigned, err := s.Fake.IsUserAssignedToTeam(r.Context(), teamID, userID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !assigned {
+		writeError(w, notFound("User is not a member of the team"))
+		return
+	}
+	writeResult(w, nil)
+}
+
+func (s *Server) assignTeamUser(w http.ResponseWriter, r *http.Request, p params) {
+	teamID, userID, ok := teamAndID(w, p, "userID")
+	if !ok {
+		return
+	}
+	err := s.Fake.AssignUserToTeam(r.Context(), teamID, userID)
+	if errors.Is(err, client.ErrNotFound) {
+		// The API returns 403 rather than 404 on an invalid assignment
+		err = apiError(http.StatusForbidden, "Forbidden")
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, nil)
+}
+
+func (s *Server) removeTeamUser(w http.ResponseWriter, r *http.Request, p params) {
+	teamID, userID, ok := teamAndID(w, p, "userID")
+	if !ok {
+		return
+	}
+	err := s.Fake.RemoveUserFromTeam(r.Context(), userID, teamID)
+	if errors.Is(err, client.ErrNotFound) {
+		// The API returns 422 rather than 404 on an invalid removal
+		err = apiError(http.StatusUnprocessableEntity, "User is not a member of the team")
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, nil)
+}
+
+func (s *Server) assignTeamProject(w http.ResponseWriter, r *http.Request, p params) {
+	teamID, projectID, ok := teamAndID(w, p, "projectID")
+	if !ok {
+		return
+	}
+	err := s.Fake.AssignTeamToProject(r.Context(), teamID, projectID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, nil)
+}
+
+func (s *Server) removeTeamProject(w http.ResponseWriter, r *http.Request, p params) {
+	teamID, projectID, ok := teamAndID(w, p, "projectID")
+	if !ok {
+		return
+	}
+	err := s.Fake.RemoveTeamFromProject(r.Context(), teamID, projectID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, nil)
+}
+
+/*
+ * Users
+ */
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request, _ params) {
+	users, err := s.Fake.ListUsers(r.Context(), r.URL.Query().Get("email"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if users == nil {
+		users = []client.User{}
+	}
+	writeResult(w, map[string]interface{}{"users": paginate(r, users)})
+}
+
+func (s *Server) readUser(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "userID")
+	if !ok {
+		return
+	}
+	u, err := s.Fake.ReadUser(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, u)
+}
+
+func (s *Server) listUserTeams(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "userID")
+	if !ok {
+		return
+	}
+	teams, err := s.Fake.ListUserTeams(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if teams == nil {
+		teams = []client.Team{}
+	}
+	writeResult(w, map[string]interface{}{"teams": paginate(r, teams)})
+}
+
+/*
+ * Invitations
+ */
+
+func (s *Server) listInvitations(w http.ResponseWriter, r *http.Request, _ params) {
+	invs, err := s.Fake.ListAllInvitationsPerEmail(r.Context(), r.URL.Query().Get("email"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, paginate(r, invs))
+}
+
+func (s *Server) listTeamInvitations(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "teamID")
+	if !ok {
+		return
+	}
+	if _, err := s.Fake.ReadTeam(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	invs, err := s.Fake.ListInvitations(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, paginate(r, invs))
+}
+
+func (s *Server) createInvitation(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "teamID")
+	if !ok {
+		return
+	}
+	var body struct {
+		Email string `json:"email"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	inv, err := s.Fake.CreateInvitation(r.Context(), id, body.Email)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, inv)
+}
+
+func (s *Server) readInvitation(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "inviteID")
+	if !ok {
+		return
+	}
+	inv, err := s.Fake.ReadInvitation(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, inv)
+}
+
+func (s *Server) cancelInvitation(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "inviteID")
+	if !ok {
+		return
+	}
+	inv, err := s.Fake.ReadInvitation(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if inv.Status == "canceled" {
+		writeError(w, apiError(http.StatusUnprocessableEntity, "Invite already canceled"))
+		return
+	}
+	err = s.Fake.CancelInvitation(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, nil)
+}
+
+/*
+ * Notifications
+ */
+
+// notificationBody is the request body for creating or updating a
+// notification rule.
+type notificationBody struct {
+	Filters interface{} `json:"filters"`
+	Trigger interface{} `json:"trigger"`
+	Config  interface{} `json:"config"`
+	Status  string      `json:"status"`
+}
+
+func (s *Server) listNotifications(w http.ResponseWriter, r *http.Request, p params) {
+	ns, err := s.Fake.ListNotifications(r.Context(), p["channel"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, paginate(r, ns))
+}
+
+func (s *Server) createNotification(w http.ResponseWriter, r *http.Request, p params) {
+	var body []notificationBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	result := make([]client.Notification, 0, len(body))
+	for _, b := range body {
+		n, err := s.Fake.CreateNotification(r.Context(), p["channel"], b.Filters, b.Trigger, b.Config, b.Status)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		result = append(result, *n)
+	}
+	writeResult(w, result)
+}
+
+func (s *Server) readNotification(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "notificationID")
+	if !ok {
+		return
+	}
+	n, err := s.Fake.ReadNotification(r.Context(), id, p["channel"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, n)
+}
+
+func (s *Server) updateNotification(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "notificationID")
+	if !ok {
+		return
+	}
+	var b notificationBody
+	if !decodeBody(w, r, &b) {
+		return
+	}
+	n, err := s.Fake.UpdateNotification(r.Context(), id, p["channel"], b.Filters, b.Trigger, b.Config, b.Status)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, n)
+}
+
+func (s *Server) deleteNotification(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "notificationID")
+	if !ok {
+		return
+	}
+	err := s.Fake.DeleteNotification(r.Context(), id, p["channel"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, nil)
+}
+
+/*
+ * Integrations
+ */
+
+func (s *Server) readIntegration(w http.ResponseWriter, r *http.Request, p params) {
+	i, err := s.Fake.ReadIntegration(r.Context(), p["integration"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, i)
+}
+
+func (s *Server) updateIntegration(w http.ResponseWriter, r *http.Request, p params) {
+	var body map[string]interface{}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	i, err := s.Fake.UpdateIntegration(r.Context(), p["integration"], body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, i)
+}
+
+/*
+ * Service links
+ */
+
+// serviceLinkBody is the request body for creating or updating a service
+// link.
+type serviceLinkBody struct {
+	Name     string `json:"name"`
+	Template string `json:"template"`
+}
+
+func (s *Server) listServiceLinks(w http.ResponseWriter, r *http.Request, _ params) {
+	links, err := s.Fake.ListSerivceLinks(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, paginate(r, links))
+}
+
+func (s *Server) createServiceLink(w http.ResponseWriter, r *http.Request, _ params) {
+	var b serviceLinkBody
+	if !decodeBody(w, r, &b) {
+		return
+	}
+	sl, err := s.Fake.CreateServiceLink(r.Context(), b.Name, b.Template)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, sl)
+}
+
+func (s *Server) readServiceLink(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "id")
+	if !ok {
+		return
+	}
+	sl, err := s.Fake.ReadServiceLink(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, sl)
+}
+
+func (s *Server) updateServiceLink(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "id")
+	if !ok {
+		return
+	}
+	var b serviceLinkBody
+	if !decodeBody(w, r, &b) {
+		return
+	}
+	sl, err := s.Fake.UpdateServiceLink(r.Context(), id, b.Name, b.Template)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, sl)
+}
+
+func (s *Server) deleteServiceLink(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := intParam(w, p, "id")
+	if !ok {
+		return
+	}
+	err := s.Fake.DeleteServiceLink(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResult(w, nil)
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServerClient starts a Server and returns it with a client pointed at it.
+func newServerClient(t *testing.T) (*Server, *client.RollbarAPIClient) {
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+	return srv, client.NewTestClient(srv.URL, "fakeTokenString")
+}
+
+// TestServerProjects checks the project lifecycle, including the API quirk of
+// deleted projects being listed with an empty name.
+func TestServerProjects(t *testing.T) {
+	ctx := context.Background()
+	srv, c := newServerClient(t)
+
+	p, err := c.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", p.Name)
+	_, err = c.CreateProject(ctx, "foo")
+	assert.ErrorIs(t, err, client.ErrUnprocessable)
+
+	pats, err := c.ListProjectAccessTokens(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Len(t, pats, 4)
+
+	read, err := c.ReadProject(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, p, read)
+
+	require.NoError(t, c.DeleteProject(ctx, p.ID))
+	_, err = c.ReadProject(ctx, p.ID)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+	projects, err := c.ListProjects(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, projects)
+	raw, err := srv.Client().Get(srv.URL + "/api/1/projects")
+	require.NoError(t, err)
+	defer raw.Body.Close()
+	assert.Equal(t, 401, raw.StatusCode) // No access token
+}
+
+// TestServerTeams checks team membership, including the API's non-standard
+// error statuses.
+func TestServerTeams(t *testing.T) {
+	ctx := context.Background()
+	srv, c := newServerClient(t)
+	user := srv.Fake.AddUser("jane@example.com", "jane")
+
+	team, err := c.CreateTeam(ctx, "team-a", "standard")
+	require.NoError(t, err)
+	err = c.AssignUserToTeam(ctx, team.ID, 9999)
+	assert.ErrorIs(t, err, client.ErrNotFound) // 403 from the API
+	err = c.RemoveUserFromTeam(ctx, user.ID, team.ID)
+	assert.ErrorIs(t, err, client.ErrNotFound) // 422 from the API
+
+	require.NoError(t, c.AssignUserToTeam(ctx, team.ID, user.ID))
+	assigned, err := c.IsUserAssignedToTeam(ctx, team.ID, user.ID)
+	require.NoError(t, err)
+	assert.True(t, assigned)
+	teams, err := c.ListUserCustomTeams(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []client.Team{team}, teams)
+	id, err := c.FindUserID(ctx, user.Email)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, id)
+
+	p, err := c.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	require.NoError(t, c.UpdateProjectTeams(ctx, p.ID, []int{team.ID}))
+	teamIDs, err := c.FindProjectTeamIDs(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{team.ID}, teamIDs)
+}
+
+// TestServerInvitations checks that canceling an invitation twice succeeds,
+// as the client treats the API's "already canceled" error as success.
+func TestServerInvitations(t *testing.T) {
+	ctx := context.Background()
+	_, c := newServerClient(t)
+
+	team, err := c.CreateTeam(ctx, "team-a", "standard")
+	require.NoError(t, err)
+	inv, err := c.CreateInvitation(ctx, team.ID, "Jane@Example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", inv.ToEmail)
+
+	pending, err := c.FindPendingInvitations(ctx, "jane@example.com")
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+	require.NoError(t, c.CancelInvitation(ctx, inv.ID))
+	require.NoError(t, c.CancelInvitation(ctx, inv.ID))
+	inv, err = c.ReadInvitation(ctx, inv.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "canceled", inv.Status)
+}
+
+// TestServerPagination checks that list endpoints are paginated.
+func TestServerPagination(t *testing.T) {
+	ctx := context.Background()
+	_, c := newServerClient(t)
+
+	for i := 0; i < 150; i++ {
+		_, err := c.CreateTeam(ctx, fmt.Sprintf("team-%d", i), "standard")
+		require.NoError(t, err)
+	}
+	teams, err := c.ListCustomTeams(ctx)
+	require.NoError(t, err)
+	assert.Len(t, teams, 150)
+}
+
+// TestServerProjectResources checks notifications, integrations and service
+// links.
+func TestServerProjectResources(t *testing.T) {
+	ctx := context.Background()
+	_, c := newServerClient(t)
+
+	n, err := c.CreateNotification(ctx, "webhook", []interface{}{}, "new_item", map[string]interface{}{}, "enabled")
+	require.NoError(t, err)
+	read, err := c.ReadNotification(ctx, n.ID, "webhook")
+	require.NoError(t, err)
+	assert.Equal(t, "new_item", read.Trigger)
+	require.NoError(t, c.DeleteNotification(ctx, n.ID, "webhook"))
+	_, err = c.ReadNotification(ctx, n.ID, "webhook")
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	_, err = c.UpdateIntegration(ctx, client.WEBHOOK, map[string]interface{}{
+		"enabled": true,
+		"url":     "https://example.com/hook",
+	})
+	require.NoError(t, err)
+	i, err := c.ReadIntegration(ctx, client.WEBHOOK)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hook", i.(*client.WebhookIntegration).Settings.URL)
+
+	sl, err := c.CreateServiceLink(ctx, "foo", "{{ foo }}")
+	require.NoError(t, err)
+	_, err = c.UpdateServiceLink(ctx, sl.ID, "bar", "{{ bar }}")
+	require.NoError(t, err)
+	links, err := c.ListSerivceLinks(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []client.ServiceLink{{ID: sl.ID, Name: "bar", Template: "{{ bar }}"}}, links)
+}
+
+// TestStartFakeAPI checks that the fake API is only started when requested,
+// and that the provider is pointed at it without overriding tokens.
+func TestStartFakeAPI(t *testing.T) {
+	t.Setenv(FakeAPIEnv, "")
+	srv, err := StartFakeAPI()
+	require.NoError(t, err)
+	assert.Nil(t, srv)
+
+	t.Setenv(FakeAPIEnv, "1")
+	t.Setenv("ROLLBAR_API_KEY", "real-api-key")
+	t.Setenv("ROLLBAR_PROJECT_API_KEY", "")
+	t.Setenv("ROLLBAR_API_URL", "")
+	srv, err = StartFakeAPI()
+	require.NoError(t, err)
+	require.NotNil(t, srv)
+	defer srv.Close()
+	assert.Equal(t, srv.URL, os.Getenv("ROLLBAR_API_URL"))
+	assert.Equal(t, "real-api-key", os.Getenv("ROLLBAR_API_KEY"))
+	assert.Equal(t, "fake-project-api-key", os.Getenv("ROLLBAR_PROJECT_API_KEY"))
+}
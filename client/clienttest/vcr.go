@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package clienttest
+
+import (
+	"net/http"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+)
+
+// RedactInteraction is a go-vcr recorder filter that masks secrets in a
+// recorded API interaction, using the same rules as the client's logging.
+// Recorders using it should also use MatchRedacted.
+func RedactInteraction(i *cassette.Interaction) error {
+	i.Request.URL = client.RedactURL(i.Request.URL)
+	i.Request.Headers = client.RedactHeader(i.Request.Headers)
+	i.Request.Body = client.RedactBody(i.Request.Body)
+	i.Response.Headers = client.RedactHeader(i.Response.Headers)
+	i.Response.Body = client.RedactBody(i.Response.Body)
+	return nil
+}
+
+// MatchRedacted is a go-vcr matcher that matches a request to an interaction
+// recorded with RedactInteraction, by method and redacted URL.
+func MatchRedacted(r *http.Request, i cassette.Request) bool {
+	return r.Method == i.Method && client.RedactURL(r.URL.String()) == i.URL
+}
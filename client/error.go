@@ -24,6 +24,7 @@ package client
 
 import (
 	"fmt"
+	"net/http"
 )
 
 // ErrorResult represents an error result returned by Rollbar API.
@@ -41,3 +42,106 @@ var ErrNotFound = fmt.Errorf("not found")
 
 // ErrUnauthorized is returned when the API returns a '401 Unauthorized' error.
 var ErrUnauthorized = fmt.Errorf("unauthorized")
+
+// ErrForbidden matches an APIError with status '403 Forbidden'.
+var ErrForbidden = fmt.Errorf("forbidden")
+
+// ErrConflict matches an APIError with status '409 Conflict'.
+var ErrConflict = fmt.Errorf("conflict")
+
+// ErrUnprocessable matches an APIError with status '422 Unprocessable Entity'.
+var ErrUnprocessable = fmt.Errorf("unprocessable entity")
+
+// ErrTooManyRequests matches an APIError with status '429 Too Many Requests'.
+var ErrTooManyRequests = fmt.Errorf("too many requests")
+
+// ErrNoToken matches a MissingTokenError.
+var ErrNoToken = fmt.Errorf("no API token configured")
+
+// MissingTokenError is returned, without contacting the API, for every request
+// made by a client that has no API token but requires one.
+type MissingTokenError struct {
+	Method     string // HTTP method of the refused request
+	Path       string // Path template of the refused request
+	Resource   string // Terraform resource making the request, if any
+	DataSource string // Terraform data source making the request, if any
+}
+
+func (e *MissingTokenError) Error() string {
+	msg := fmt.Sprintf("%s: %s %s", ErrNoToken, e.Method, e.Path)
+	switch {
+	case e.Resource != "":
+		msg += " for resource " + e.Resource
+	case e.DataSource != "":
+		msg += " for data source " + e.DataSource
+	}
+	return msg
+}
+
+// Is reports whether target is ErrNoToken.
+func (e *MissingTokenError) Is(target error) bool {
+	return target == ErrNoToken
+}
+
+// APIError is returned when the Rollbar API responds with an error status.  It
+// matches the sentinel error for its status code with errors.Is, e.g.
+// errors.Is(err, ErrNotFound) for a 404.
+type APIError struct {
+	StatusCode int    // HTTP status code
+	Method     string // HTTP method of the failed request
+	URL        string // URL of the failed request
+	Err        int    // Rollbar error code from the response body
+	Message    string // Rollbar error message from the response body
+	RequestID  string // Value of the X-Request-Id response header, if any
+	Body       string // Raw response body
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.StatusCode, msg)
+}
+
+// Is reports whether target is the sentinel error for e's status code.
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusConflict:
+		return target == ErrConflict
+	case http.StatusUnprocessableEntity:
+		return target == ErrUnprocessable
+	case http.StatusTooManyRequests:
+		return target == ErrTooManyRequests
+	}
+	return false
+}
+
+// Hint returns a short suggestion for resolving the error, or an empty string
+// if there is none.
+func (e *APIError) Hint() string {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return "check that the API key is valid and enabled"
+	case http.StatusForbidden:
+		return "token lacks write scope, or belongs to a different account or project"
+	case http.StatusNotFound:
+		return "the object does not exist, or is not visible to this token"
+	case http.StatusConflict:
+		return "an object with the same name may already exist"
+	case http.StatusUnprocessableEntity:
+		return "the API rejected the request arguments; check the resource configuration"
+	case http.StatusTooManyRequests:
+		return "the token's rate limit was exceeded; reduce parallelism or retry later"
+	}
+	if e.StatusCode >= http.StatusInternalServerError {
+		return "the Rollbar API is having problems; retry later"
+	}
+	return ""
+}
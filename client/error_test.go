@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// TestAPIError tests that error responses from the API are returned as an
+// *APIError matching the appropriate sentinel error.
+func (s *Suite) TestAPIError() {
+	projectID := 411708
+	u := s.client.BaseURL + pathProjectRead
+	u = strings.ReplaceAll(u, "{projectID}", strconv.Itoa(projectID))
+
+	sentinels := map[int]error{
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrForbidden,
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusConflict:            ErrConflict,
+		http.StatusUnprocessableEntity: ErrUnprocessable,
+		http.StatusTooManyRequests:     ErrTooManyRequests,
+	}
+	for status, sentinel := range sentinels {
+		r := httpmock.NewJsonResponderOrPanic(status,
+			ErrorResult{Err: 1, Message: "test message"})
+		httpmock.RegisterResponder("GET", u, r)
+		_, err := s.client.ReadProject(context.Background(), projectID)
+		s.ErrorIs(err, sentinel)
+		var ae *APIError
+		s.True(errors.As(err, &ae))
+		s.Equal(status, ae.StatusCode)
+		s.Equal("GET", ae.Method)
+		s.Equal(u, ae.URL)
+		s.Equal(1, ae.Err)
+		s.Equal("test message", ae.Message)
+		s.NotEmpty(ae.Hint())
+		s.Contains(err.Error(), u)
+		for _, other := range sentinels {
+			if other != sentinel {
+				s.NotErrorIs(err, other)
+			}
+		}
+	}
+}
+
+// TestAPIErrorNonJSON tests that a non-JSON error response, such as an HTML
+// page from a proxy, is handled without panicking.
+func (s *Suite) TestAPIErrorNonJSON() {
+	projectID := 411708
+	u := s.client.BaseURL + pathProjectRead
+	u = strings.ReplaceAll(u, "{projectID}", strconv.Itoa(projectID))
+
+	body := "<html><body>Service Unavailable</body></html>"
+	rs := httpmock.NewStringResponse(http.StatusServiceUnavailable, body)
+	rs.Header.Set("Content-Type", "text/html")
+	rs.Header.Set("X-Request-Id", "abc123")
+	httpmock.RegisterResponder("GET", u, httpmock.ResponderFromResponse(rs))
+	_, err := s.client.ReadProject(context.Background(), projectID)
+	var ae *APIError
+	s.True(errors.As(err, &ae))
+	s.Equal(http.StatusServiceUnavailable, ae.StatusCode)
+	s.Equal(body, ae.Body)
+	s.Equal("abc123", ae.RequestID)
+	s.Contains(err.Error(), "Service Unavailable")
+}
@@ -0,0 +1,412 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// harVersion is the version of the HTTP Archive format written by
+// HARRecorder.
+const harVersion = "1.2"
+
+// HAR is an HTTP Archive, as specified at
+// http://www.softwareishard.com/blog/har-12-spec/.  Only the fields the
+// client records are supported.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the root object of an HTTP Archive.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator identifies the application that wrote an HTTP Archive.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is one request and its response.
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"` // Milliseconds
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+
+	// Error is why no response was received, if so.  Custom HAR fields
+	// start with an underscore.
+	Error string `json:"_error,omitempty"`
+}
+
+// HARRequest is a recorded HTTP request.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse is a recorded HTTP response.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARNameValue is a header, cookie or query parameter.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is the body of a recorded request.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent is the body of a recorded response.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARTimings breaks down the time taken by a request, in milliseconds.  The
+// client only knows the total, which it reports as Wait.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// ReadHAR reads an HTTP Archive from the file at path.
+func ReadHAR(path string) (*HAR, error) {
+	b, err := os.ReadFile(path) // #nosec
+	if err != nil {
+		return nil, err
+	}
+	var har HAR
+	err = json.Unmarshal(b, &har)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing HAR file %s: %w", path, err)
+	}
+	return &har, nil
+}
+
+// harTrailer ends an archive file written by HARRecorder.  Each new entry is
+// written over it, followed by the trailer again.
+const harTrailer = "\n]}}\n"
+
+// HARRecorder records API requests and responses in an HTTP Archive, with
+// secrets masked as in the client's logs.  It is safe for concurrent use, and
+// may be shared between clients.
+type HARRecorder struct {
+	m       sync.Mutex
+	path    string
+	written int        // Entries in the file at path
+	entries []HAREntry // Entries recorded, if there is no file
+}
+
+// NewHARRecorder returns a HARRecorder that adds each entry to the file at
+// path as soon as it is recorded, so the archive survives a crash without
+// being rewritten or kept in memory.  If the file already holds an archive,
+// new entries are added to it.  If path is empty, entries are only kept in
+// memory.
+func NewHARRecorder(path string) (*HARRecorder, error) {
+	r := &HARRecorder{path: path}
+	if path == "" {
+		return r, nil
+	}
+	var entries []HAREntry
+	har, err := ReadHAR(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		entries = har.Log.Entries
+	}
+	// Rewrite the file once, so that entries can be added before harTrailer
+	err = r.create(entries)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// HAR returns the archive recorded so far.
+func (r *HARRecorder) HAR() (*HAR, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.path != "" {
+		return ReadHAR(r.path)
+	}
+	return newHAR(append([]HAREntry{}, r.entries...)), nil
+}
+
+// newHAR returns an archive of entries.
+func newHAR(entries []HAREntry) *HAR {
+	return &HAR{Log: HARLog{
+		Version: harVersion,
+		Creator: HARCreator{Name: "terraform-provider-rollbar", Version: Version},
+		Entries: entries,
+	}}
+}
+
+// add records e, adding it to the archive file if there is one.
+func (r *HARRecorder) add(e HAREntry) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.path == "" {
+		r.entries = append(r.entries, e)
+		return
+	}
+	err := r.append(e)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("path", r.path).
+			Msg("Error writing HAR file")
+	}
+}
+
+// create replaces the archive file with an archive of entries, ending in
+// harTrailer.  The caller must hold r.m, or be the constructor.
+func (r *HARRecorder) create(entries []HAREntry) error {
+	b, err := json.Marshal(newHAR([]HAREntry{}))
+	if err != nil {
+		return err
+	}
+	// Entries is the last field, so b ends with `[]}}`
+	buf := bytes.NewBuffer(b[:len(b)-len("]}}")])
+	for i, e := range entries {
+		err = writeHAREntry(buf, e, i == 0)
+		if err != nil {
+			return err
+		}
+	}
+	buf.WriteString(harTrailer)
+	tmp := r.path + ".tmp"
+	err = os.WriteFile(tmp, buf.Bytes(), 0o600)
+	if err != nil {
+		return err
+	}
+	r.written = len(entries)
+	return os.Rename(tmp, r.path)
+}
+
+// append adds e to the archive file, over its trailer.  The caller must hold
+// r.m.
+func (r *HARRecorder) append(e HAREntry) error {
+	f, err := os.OpenFile(r.path, os.O_RDWR, 0o600) // #nosec
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.Size() < int64(len(harTrailer)) {
+		return fmt.Errorf("HAR file %s is truncated", r.path)
+	}
+	var buf bytes.Buffer
+	err = writeHAREntry(&buf, e, r.written == 0)
+	if err != nil {
+		return err
+	}
+	buf.WriteString(harTrailer)
+	_, err = f.WriteAt(buf.Bytes(), fi.Size()-int64(len(harTrailer)))
+	if err != nil {
+		return err
+	}
+	r.written++
+	return f.Close()
+}
+
+// writeHAREntry writes e to buf on a line of its own, after a comma unless it
+// is the first entry.
+func writeHAREntry(buf *bytes.Buffer, e HAREntry, first bool) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if !first {
+		buf.WriteByte(',')
+	}
+	buf.WriteByte('\n')
+	buf.Write(b)
+	return nil
+}
+
+// harTransport is an http.RoundTripper that records every request sent
+// through it, and its response, in a HARRecorder.
+type harTransport struct {
+	next     http.RoundTripper
+	recorder *HARRecorder
+}
+
+// newHARTransport wraps next, recording requests in recorder.  A nil next
+// means http.DefaultTransport.
+func newHARTransport(next http.RoundTripper, recorder *HARRecorder) *harTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &harTransport{next: next, recorder: recorder}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *harTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	e := HAREntry{
+		StartedDateTime: start.UTC(),
+		Request: HARRequest{
+			Method:      req.Method,
+			URL:         RedactURL(req.URL.String()),
+			HTTPVersion: req.Proto,
+			Cookies:     []HARNameValue{},
+			Headers:     harHeaders(RedactHeader(req.Header)),
+			QueryString: []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+	}
+	for k, vs := range req.URL.Query() {
+		for _, v := range vs {
+			e.Request.QueryString = append(e.Request.QueryString, HARNameValue{Name: k, Value: RedactString(v)})
+		}
+	}
+	sort.Slice(e.Request.QueryString, func(i, j int) bool {
+		return e.Request.QueryString[i].Name < e.Request.QueryString[j].Name
+	})
+	if reqBody != nil {
+		e.Request.PostData = &HARPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     RedactBody(string(reqBody)),
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+	e.Time = elapsed
+	e.Timings.Wait = elapsed
+	e.Response = HARResponse{
+		Cookies:     []HARNameValue{},
+		Headers:     []HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	if err != nil {
+		e.Error = RedactString(err.Error())
+		t.recorder.add(e)
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if err != nil {
+		e.Error = RedactString(err.Error())
+		t.recorder.add(e)
+		return resp, err
+	}
+	e.Response.Status = resp.StatusCode
+	e.Response.StatusText = http.StatusText(resp.StatusCode)
+	e.Response.HTTPVersion = resp.Proto
+	e.Response.Headers = harHeaders(RedactHeader(resp.Header))
+	e.Response.BodySize = len(respBody)
+	e.Response.Content = HARContent{
+		Size:     len(respBody),
+		MimeType: resp.Header.Get("Content-Type"),
+		Text:     RedactBody(string(respBody)),
+	}
+	t.recorder.add(e)
+	return resp, nil
+}
+
+// peekRequestBody returns the body of req, if any, leaving it unread.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	b, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return b, err
+}
+
+// harHeaders converts h to HAR name/value pairs, sorted by name.
+func harHeaders(h http.Header) []HARNameValue {
+	nvs := []HARNameValue{}
+	for k, vs := range h {
+		for _, v := range vs {
+			nvs = append(nvs, HARNameValue{Name: k, Value: v})
+		}
+	}
+	sort.SliceStable(nvs, func(i, j int) bool {
+		return nvs[i].Name < nvs[j].Name
+	})
+	return nvs
+}
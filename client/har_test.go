@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+)
+
+// TestHARRecorderAppend checks that a HAR recorder adds to an existing
+// archive file rather than replacing it.
+func (s *Suite) TestHARRecorderAppend() {
+	srv, _ := s.rateLimitServer(func(n int, w http.ResponseWriter) {
+		writeProject(w)
+	})
+	path := filepath.Join(s.T().TempDir(), "rollbar.har")
+	for i := 1; i <= 2; i++ {
+		rec, err := NewHARRecorder(path)
+		s.Require().NoError(err)
+		opts := DefaultOptions()
+		opts.HAR = rec
+		c := NewClientWithOptions(srv.URL, "fakeTokenString", opts)
+		_, err = c.ReadProject(context.Background(), 1)
+		s.Nil(err)
+
+		har, err := ReadHAR(path)
+		s.Require().NoError(err)
+		s.Equal(harVersion, har.Log.Version)
+		s.Len(har.Log.Entries, i)
+		recorded, err := rec.HAR()
+		s.Require().NoError(err)
+		s.Equal(recorded, har)
+	}
+}
+
+// TestHARRecorderError checks that a request that gets no response is
+// recorded with the error.
+func (s *Suite) TestHARRecorderError() {
+	rec, err := NewHARRecorder("")
+	s.Require().NoError(err)
+	opts := DefaultOptions()
+	opts.MaxRetries = 0
+	opts.HAR = rec
+	c := NewClientWithOptions("http://127.0.0.1:1", "fakeTokenString", opts)
+	_, err = c.ReadProject(context.Background(), 1)
+	s.NotNil(err)
+
+	har, err := rec.HAR()
+	s.Require().NoError(err)
+	entries := har.Log.Entries
+	s.Require().Len(entries, 1)
+	s.Equal("http://127.0.0.1:1/api/1/project/1", entries[0].Request.URL)
+	s.NotEmpty(entries[0].Error)
+	s.Zero(entries[0].Response.Status)
+}
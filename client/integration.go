@@ -23,6 +23,8 @@
 package client
 
 import (
+	"context"
+
 	"github.com/rs/zerolog/log"
 )
 
@@ -71,15 +73,14 @@ type WebhookIntegration struct {
 }
 
 // UpdateIntegration updates a new Rollbar integration.
-func (c *RollbarAPIClient) UpdateIntegration(integration string, bodyMap map[string]interface{}) (interface{}, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) UpdateIntegration(ctx context.Context, integration string, bodyMap map[string]interface{}) (interface{}, error) {
 	u := c.BaseURL + pathIntegration
 	l := log.With().
 		Str("integration", integration).
 		Logger()
 	l.Debug().Msg("Update integration")
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetBody(bodyMap).
 		SetResult(Integrations[integration]).
 		SetError(ErrorResult{}).
@@ -113,9 +114,7 @@ func (c *RollbarAPIClient) UpdateIntegration(integration string, bodyMap map[str
 
 // ReadIntegration reads a Rollbar integration from the API. If no matching integration is found,
 // returns error ErrNotFound.
-func (c *RollbarAPIClient) ReadIntegration(integration string) (interface{}, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ReadIntegration(ctx context.Context, integration string) (interface{}, error) {
 	u := c.BaseURL + pathIntegration
 
 	l := log.With().
@@ -124,6 +123,7 @@ func (c *RollbarAPIClient) ReadIntegration(integration string) (interface{}, err
 	l.Debug().Msg("Reading Integration from API")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetResult(Integrations[integration]).
 		SetError(ErrorResult{}).
 		SetPathParams(map[string]string{
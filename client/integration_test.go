@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -58,13 +59,13 @@ func (s *Suite) TestUpdateIntegraion() {
 	}
 
 	httpmock.RegisterResponder("PUT", u, r)
-	integ, err := s.client.UpdateIntegration(integration, bodyMap)
+	integ, err := s.client.UpdateIntegration(context.Background(), integration, bodyMap)
 	slackIntegration := integ.(*SlackIntegration)
 	s.Nil(err)
 	s.Equal(id, slackIntegration.ProjectID)
 
 	s.checkServerErrors("PUT", u, func() error {
-		_, err = s.client.UpdateIntegration(integration, bodyMap)
+		_, err = s.client.UpdateIntegration(context.Background(), integration, bodyMap)
 
 		return err
 	})
@@ -86,7 +87,7 @@ func (s *Suite) TestReadIntegration() {
 	// Success
 	r := responderFromFixture("integration/read.json", http.StatusOK)
 	httpmock.RegisterResponder("GET", u, r)
-	integ, err := s.client.ReadIntegration(integration)
+	integ, err := s.client.ReadIntegration(context.Background(), integration)
 	slackIntegration := integ.(*SlackIntegration)
 	s.Nil(err)
 	s.Equal(serviceAccountID, slackIntegration.Settings.ServiceAccountID)
@@ -95,7 +96,7 @@ func (s *Suite) TestReadIntegration() {
 	s.Equal(channel, slackIntegration.Settings.Channel)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ReadIntegration(integration)
+		_, err := s.client.ReadIntegration(context.Background(), integration)
 		return err
 	})
 }
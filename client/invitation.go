@@ -23,6 +23,8 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -42,41 +44,16 @@ type Invitation struct {
 }
 
 // ListAllInvitationsPerEmail lists all invitations for all Rollbar teams.
-func (c *RollbarAPIClient) ListAllInvitationsPerEmail(email string) (invs []Invitation, err error) {
-	c.m.Lock()
-	defer c.m.Unlock()
-	hasNextPage := true
-	page := 1
-
+func (c *RollbarAPIClient) ListAllInvitationsPerEmail(ctx context.Context, email string) (invs []Invitation, err error) {
 	l := log.With().
 		Str("email", email).
 		Logger()
 	l.Debug().Msg("Listing invitations")
 
-	for hasNextPage {
-		resp, err := c.Resty.R().
-			SetResult(invitationListResponse{}).
-			SetError(ErrorResult{}).
-			SetQueryParams(map[string]string{
-				"page":  strconv.Itoa(page),
-				"email": email,
-			}).
-			Get(c.BaseURL + pathInvitations)
-		if err != nil {
-			l.Err(err).Msg("Error listing invitations")
-			return nil, err
-		}
-		err = errorFromResponse(resp)
-		if err != nil {
-			l.Err(err).
-				Str("status", resp.Status()).
-				Msg("Error listing invitations")
-			return nil, err
-		}
-		r := resp.Result().(*invitationListResponse)
-		hasNextPage = len(r.Result) > 0
-		page++
-		invs = append(invs, r.Result...)
+	invs, err = c.listInvitations(ctx, c.BaseURL+pathInvitations, nil, map[string]string{"email": email})
+	if err != nil {
+		l.Err(err).Msg("Error listing invitations")
+		return nil, err
 	}
 	l.Debug().
 		Int("invitation_count", len(invs)).
@@ -85,55 +62,53 @@ func (c *RollbarAPIClient) ListAllInvitationsPerEmail(email string) (invs []Invi
 }
 
 // ListInvitations lists all invitations for a Rollbar team.
-func (c *RollbarAPIClient) ListInvitations(teamID int) (invs []Invitation, err error) {
-	c.m.Lock()
-	defer c.m.Unlock()
-	hasNextPage := true
-	page := 1
-
+func (c *RollbarAPIClient) ListInvitations(ctx context.Context, teamID int) (invs []Invitation, err error) {
 	l := log.With().
 		Int("teamID", teamID).
 		Logger()
 	l.Debug().Msg("Listing invitations")
 
-	for hasNextPage {
+	pathParams := map[string]string{"teamID": strconv.Itoa(teamID)}
+	invs, err = c.listInvitations(ctx, c.BaseURL+pathTeamInvitations, pathParams, nil)
+	if err != nil {
+		l.Err(err).Msg("Error listing invitations")
+		return nil, err
+	}
+	l.Debug().
+		Int("invitation_count", len(invs)).
+		Msg("Successfully listed invitations")
+	return invs, nil
+}
+
+// listInvitations lists all invitations at URL u, with the given path and
+// query parameters.
+func (c *RollbarAPIClient) listInvitations(ctx context.Context, u string, pathParams, query map[string]string) ([]Invitation, error) {
+	return listAll(ctx, func(ctx context.Context, page, perPage int) ([]Invitation, error) {
 		resp, err := c.Resty.R().
-			SetPathParams(map[string]string{
-				"teamID": strconv.Itoa(teamID),
-			}).
+			SetContext(ctx).
+			SetPathParams(pathParams).
 			SetResult(invitationListResponse{}).
 			SetError(ErrorResult{}).
-			SetQueryParams(map[string]string{
-				"page": strconv.Itoa(page)}).
-			Get(c.BaseURL + pathTeamInvitations)
+			SetQueryParams(query).
+			SetQueryParams(pageParams(page, perPage)).
+			Get(u)
 		if err != nil {
-			l.Err(err).Msg("Error listing invitations")
 			return nil, err
 		}
 		err = errorFromResponse(resp)
 		if err != nil {
-			l.Err(err).
-				Str("status", resp.Status()).
-				Msg("Error listing invitations")
 			return nil, err
 		}
-		r := resp.Result().(*invitationListResponse)
-		hasNextPage = len(r.Result) > 0
-		page++
-		invs = append(invs, r.Result...)
-	}
-	l.Debug().
-		Int("invitation_count", len(invs)).
-		Msg("Successfully listed invitations")
-	return invs, nil
+		return resp.Result().(*invitationListResponse).Result, nil
+	}, func(inv Invitation) int { return inv.ID })
 }
 
 // ListPendingInvitations lists a Rollbar team's pending invitations.
-func (c *RollbarAPIClient) ListPendingInvitations(teamID int) ([]Invitation, error) {
+func (c *RollbarAPIClient) ListPendingInvitations(ctx context.Context, teamID int) ([]Invitation, error) {
 	l := log.With().Int("teamID", teamID).Logger()
 	l.Debug().Msg("Listing pending invitations")
 	var pending []Invitation
-	all, err := c.ListInvitations(teamID)
+	all, err := c.ListInvitations(ctx, teamID)
 	if err != nil {
 		l.Err(err).Send()
 		return pending, err
@@ -151,11 +126,11 @@ func (c *RollbarAPIClient) ListPendingInvitations(teamID int) ([]Invitation, err
 
 // FindPendingInvitations finds pending Rollbar team invitations for the given
 // email.
-func (c *RollbarAPIClient) FindPendingInvitations(email string) ([]Invitation, error) {
+func (c *RollbarAPIClient) FindPendingInvitations(ctx context.Context, email string) ([]Invitation, error) {
 	l := log.With().Str("email", email).Logger()
 	l.Debug().Msg("Finding pending invitations")
 	var pending []Invitation
-	all, err := c.FindInvitations(email)
+	all, err := c.FindInvitations(ctx, email)
 	if err != nil {
 		l.Err(err).Send()
 		return pending, err
@@ -172,9 +147,7 @@ func (c *RollbarAPIClient) FindPendingInvitations(email string) ([]Invitation, e
 }
 
 // CreateInvitation sends a Rollbar team invitation to a user.
-func (c *RollbarAPIClient) CreateInvitation(teamID int, email string) (Invitation, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) CreateInvitation(ctx context.Context, teamID int, email string) (Invitation, error) {
 	l := log.With().
 		Int("teamID", teamID).
 		Str("email", email).
@@ -184,6 +157,7 @@ func (c *RollbarAPIClient) CreateInvitation(teamID int, email string) (Invitatio
 	u := c.BaseURL + pathTeamInvitations
 	var inv Invitation
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"teamID": strconv.Itoa(teamID),
 		}).
@@ -209,9 +183,7 @@ func (c *RollbarAPIClient) CreateInvitation(teamID int, email string) (Invitatio
 }
 
 // ReadInvitation reads a Rollbar team invitation from the API.
-func (c *RollbarAPIClient) ReadInvitation(inviteID int) (inv Invitation, err error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ReadInvitation(ctx context.Context, inviteID int) (inv Invitation, err error) {
 	l := log.With().
 		Int("inviteID", inviteID).
 		Logger()
@@ -219,6 +191,7 @@ func (c *RollbarAPIClient) ReadInvitation(inviteID int) (inv Invitation, err err
 	u := c.BaseURL + pathInvitation
 	u = strings.ReplaceAll(u, "{inviteID}", strconv.Itoa(inviteID))
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetResult(invitationResponse{}).
 		SetError(ErrorResult{}).
 		Get(u)
@@ -239,19 +212,18 @@ func (c *RollbarAPIClient) ReadInvitation(inviteID int) (inv Invitation, err err
 }
 
 // DeleteInvitation is an alias for CancelInvitation.
-func (c *RollbarAPIClient) DeleteInvitation(id int) (err error) {
-	return c.CancelInvitation(id)
+func (c *RollbarAPIClient) DeleteInvitation(ctx context.Context, id int) (err error) {
+	return c.CancelInvitation(ctx, id)
 }
 
 // CancelInvitation cancels a Rollbar team invitation.
-func (c *RollbarAPIClient) CancelInvitation(id int) (err error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) CancelInvitation(ctx context.Context, id int) (err error) {
 	l := log.With().Int("id", id).Logger()
 	l.Debug().Msg("Canceling invitation")
 
 	u := c.BaseURL + pathInvitation
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"inviteID": strconv.Itoa(id),
 		}).
@@ -284,7 +256,7 @@ func (c *RollbarAPIClient) CancelInvitation(id int) (err error) {
 }
 
 // FindInvitations finds all Rollbar team invitations for a given email.
-func (c *RollbarAPIClient) FindInvitations(email string) (invs []Invitation, err error) {
+func (c *RollbarAPIClient) FindInvitations(ctx context.Context, email string) (invs []Invitation, err error) {
 	// API converts all invited emails to lowercase.
 	// https://github.com/rollbar/terraform-provider-rollbar/issues/139
 	email = strings.ToLower(email)
@@ -293,8 +265,8 @@ func (c *RollbarAPIClient) FindInvitations(email string) (invs []Invitation, err
 		Logger()
 
 	l.Debug().Msg("Finding invitations")
-	invs, err = c.ListAllInvitationsPerEmail(email)
-	if err != nil && err != ErrNotFound {
+	invs, err = c.ListAllInvitationsPerEmail(ctx, email)
+	if err != nil && !errors.Is(err, ErrNotFound) {
 		l.Err(err).
 			Msg("error finding invitations")
 		return invs, err
@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -39,10 +40,11 @@ func (s *Suite) TestListInvitations() {
 
 	// Success
 	r := responderFromFixture("invitation/list.json", http.StatusOK)
-	httpmock.RegisterResponder("GET", u+"?page=1", r)
+	httpmock.RegisterResponder("GET", u+"?page=1&per_page=100", r)
 
+	// Empty page 2 ends the list
 	r = responderFromFixture("invitation/list_662036.json", http.StatusOK)
-	httpmock.RegisterResponder("GET", u+"?page=2", r)
+	httpmock.RegisterResponder("GET", u+"?page=2&per_page=100", r)
 
 	expected := []Invitation{
 		{
@@ -64,12 +66,13 @@ func (s *Suite) TestListInvitations() {
 			ToEmail:      "jason.mcvetta+test4@gmail.com",
 		},
 	}
-	actual, err := s.client.ListInvitations(teamID)
+	actual, err := s.client.ListInvitations(context.Background(), teamID)
 	s.Nil(err)
 	s.ElementsMatch(expected, actual)
+	s.Equal(1, httpmock.GetCallCountInfo()["GET "+u+"?page=2&per_page=100"])
 
-	s.checkServerErrors("GET", u+"?page=1", func() error {
-		_, err := s.client.ListInvitations(teamID)
+	s.checkServerErrors("GET", u+"?page=1&per_page=100", func() error {
+		_, err := s.client.ListInvitations(context.Background(), teamID)
 		return err
 	})
 }
@@ -81,14 +84,14 @@ func (s *Suite) TestListPendingInvitations() {
 
 	u := s.client.BaseURL + pathTeamInvitations
 	u = strings.ReplaceAll(u, "{teamID}", strconv.Itoa(teamID))
-	expectedQuery := map[string]string{"page": "1"}
+	expectedQuery := map[string]string{"page": "1", "per_page": "100"}
 	// Success
 	r := responderFromFixture("invitation/list_662037.json", http.StatusOK)
 	httpmock.RegisterResponderWithQuery("GET", u, expectedQuery, r)
 
-	// Success
+	// Empty page 2 ends the list
 	r = responderFromFixture("invitation/list_662036.json", http.StatusOK)
-	httpmock.RegisterResponderWithQuery("GET", u, "page=2", r)
+	httpmock.RegisterResponderWithQuery("GET", u, "page=2&per_page=100", r)
 	expected := []Invitation{
 		{
 			ID:           153648,
@@ -100,11 +103,11 @@ func (s *Suite) TestListPendingInvitations() {
 			DateRedeemed: 0,
 		},
 	}
-	actual, err := s.client.ListPendingInvitations(teamID)
+	actual, err := s.client.ListPendingInvitations(context.Background(), teamID)
 	s.Nil(err)
 	s.ElementsMatch(expected, actual)
-	s.checkServerErrors("GET", u+"?page=1", func() error {
-		_, err := s.client.ListPendingInvitations(teamID)
+	s.checkServerErrors("GET", u+"?page=1&per_page=100", func() error {
+		_, err := s.client.ListPendingInvitations(context.Background(), teamID)
 		return err
 	})
 }
@@ -129,12 +132,12 @@ func (s *Suite) TestCreateInvitation() {
 		return rs, nil
 	}
 	httpmock.RegisterResponder("POST", u, r)
-	inv, err := s.client.CreateInvitation(teamID, email)
+	inv, err := s.client.CreateInvitation(context.Background(), teamID, email)
 	s.Nil(err)
 	s.Equal(email, inv.ToEmail)
 
 	s.checkServerErrors("POST", u, func() error {
-		_, err = s.client.CreateInvitation(teamID, email)
+		_, err = s.client.CreateInvitation(context.Background(), teamID, email)
 		return err
 	})
 }
@@ -148,7 +151,7 @@ func (s *Suite) TestReadInvitation() {
 	// Success
 	r := responderFromFixture("invitation/read.json", http.StatusOK)
 	httpmock.RegisterResponder("GET", u, r)
-	actual, err := s.client.ReadInvitation(id)
+	actual, err := s.client.ReadInvitation(context.Background(), id)
 	s.Nil(err)
 	expected := Invitation{
 		DateCreated:  1603192477,
@@ -162,7 +165,7 @@ func (s *Suite) TestReadInvitation() {
 	s.Equal(expected, actual)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ReadInvitation(id)
+		_, err := s.client.ReadInvitation(context.Background(), id)
 		return err
 	})
 }
@@ -174,22 +177,22 @@ func (s *Suite) TestCancelInvitation() {
 
 	r := responderFromFixture("invitation/cancel.json", http.StatusOK)
 	httpmock.RegisterResponder("DELETE", u, r)
-	err := s.client.CancelInvitation(invitationID)
+	err := s.client.CancelInvitation(context.Background(), invitationID)
 	s.Nil(err)
 
 	// DeleteInvitation is an alias for CancelInvitation.
-	err = s.client.DeleteInvitation(invitationID)
+	err = s.client.DeleteInvitation(context.Background(), invitationID)
 	s.Nil(err)
 
 	// Invitation is already cancelled
 	r = httpmock.NewJsonResponderOrPanic(http.StatusUnprocessableEntity,
 		ErrorResult{Err: 1, Message: "Invite already cancelled"})
 	httpmock.RegisterResponder("DELETE", u, r)
-	err = s.client.CancelInvitation(invitationID)
+	err = s.client.CancelInvitation(context.Background(), invitationID)
 	s.NotNil(err)
 
 	s.checkServerErrors("DELETE", u, func() error {
-		err := s.client.CancelInvitation(invitationID)
+		err := s.client.CancelInvitation(context.Background(), invitationID)
 		return err
 	})
 
@@ -199,13 +202,13 @@ func (s *Suite) TestFindInvitations() {
 	email := "jason.mcvetta+test10@gmail.com"
 
 	u := s.client.BaseURL + pathInvitations
-	expectedQuery := map[string]string{"page": "1", "email": email}
+	expectedQuery := map[string]string{"page": "1", "per_page": "100", "email": email}
 	fixturePath := "invitation/list_all.json"
 	// Success
 	r := responderFromFixture(fixturePath, http.StatusOK)
 	httpmock.RegisterResponderWithQuery("GET", u, expectedQuery, r)
 
-	// Success
+	// Empty page 2 ends the list
 	expectedQuery["page"] = "2"
 	r = responderFromFixture("invitation/list_662036.json", http.StatusOK)
 	httpmock.RegisterResponderWithQuery("GET", u, expectedQuery, r)
@@ -226,16 +229,16 @@ func (s *Suite) TestFindInvitations() {
 			DateRedeemed: 0,
 		},
 	}
-	actual, err := s.client.FindInvitations(email)
+	actual, err := s.client.FindInvitations(context.Background(), email)
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	// No invitations found
-	_, err = s.client.FindInvitations("nonexistent@email.com")
-	s.Equal(ErrNotFound, err)
+	_, err = s.client.FindInvitations(context.Background(), "nonexistent@email.com")
+	s.ErrorIs(err, ErrNotFound)
 
 	s.checkServerErrorsWithQuery("GET", u, expectedQuery, func() error {
-		_, err := s.client.FindInvitations("nonexistent@email.com")
+		_, err := s.client.FindInvitations(context.Background(), "nonexistent@email.com")
 		return err
 	})
 }
@@ -246,13 +249,13 @@ func (s *Suite) TestFindPendingInvitations() {
 	email := "jason.mcvetta+test10@gmail.com"
 
 	u := s.client.BaseURL + pathInvitations
-	expectedQuery := map[string]string{"page": "1", "email": email}
+	expectedQuery := map[string]string{"page": "1", "per_page": "100", "email": email}
 	fixturePath := "invitation/list_all.json"
 	// Success
 	r := responderFromFixture(fixturePath, http.StatusOK)
 	httpmock.RegisterResponderWithQuery("GET", u, expectedQuery, r)
 
-	// Success
+	// Empty page 2 ends the list
 	expectedQuery["page"] = "2"
 	r = responderFromFixture("invitation/list_662036.json", http.StatusOK)
 	httpmock.RegisterResponderWithQuery("GET", u, expectedQuery, r)
@@ -270,12 +273,12 @@ func (s *Suite) TestFindPendingInvitations() {
 			DateRedeemed: 0,
 		},
 	}
-	actual, err := s.client.FindPendingInvitations(email)
+	actual, err := s.client.FindPendingInvitations(context.Background(), email)
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	s.checkServerErrorsWithQuery("GET", u, expectedQuery, func() error {
-		_, err := s.client.FindPendingInvitations("nonexistent@email.com")
+		_, err := s.client.FindPendingInvitations(context.Background(), "nonexistent@email.com")
 		return err
 	})
 }
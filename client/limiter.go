@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"net/http"
+)
+
+// limitTransport is an http.RoundTripper that bounds the number of requests in
+// flight through the wrapped transport.
+type limitTransport struct {
+	next http.RoundTripper
+	sem  chan struct{}
+}
+
+// newLimitTransport wraps next, allowing at most n concurrent requests.  A nil
+// next means http.DefaultTransport.
+func newLimitTransport(next http.RoundTripper, n int) *limitTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &limitTransport{
+		next: next,
+		sem:  make(chan struct{}, n),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.  It blocks until a slot is free or
+// the request's context is done.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-t.sem }()
+	return t.next.RoundTrip(req)
+}
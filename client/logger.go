@@ -27,21 +27,22 @@ import (
 	"github.com/rs/zerolog"
 )
 
-// restyZeroLogger implements resty.Logger on top of zerolog.Logger
+// restyZeroLogger implements resty.Logger on top of zerolog.Logger, masking
+// secrets in messages.
 type restyZeroLogger struct {
 	zl zerolog.Logger
 }
 
 func (r restyZeroLogger) Errorf(format string, v ...interface{}) {
-	msg := fmt.Sprintf(format, v...)
+	msg := RedactString(fmt.Sprintf(format, v...))
 	r.zl.Error().Msg(msg)
 }
 func (r restyZeroLogger) Warnf(format string, v ...interface{}) {
-	msg := fmt.Sprintf(format, v...)
+	msg := RedactString(fmt.Sprintf(format, v...))
 	r.zl.Warn().Msg(msg)
 }
 
 func (r restyZeroLogger) Debugf(format string, v ...interface{}) {
-	msg := fmt.Sprintf(format, v...)
+	msg := RedactString(fmt.Sprintf(format, v...))
 	r.zl.Debug().Msg(msg)
 }
@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"github.com/go-resty/resty/v2"
 	"github.com/jarcoal/httpmock"
 	"net/http"
@@ -42,7 +43,7 @@ func (s *Suite) TestRestyZeroLogger() {
 	lpr := projectListResponse{}
 	rOk := httpmock.NewJsonResponderOrPanic(http.StatusOK, lpr)
 	httpmock.RegisterResponder("GET", u, rOk)
-	_, err := s.client.ListProjects()
+	_, err := s.client.ListProjects(context.Background())
 	s.Nil(err)
 
 	// Warn log
@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
@@ -40,9 +41,7 @@ type Notification struct {
 }
 
 // CreateNotification creates a new Rollbar notification.
-func (c *RollbarAPIClient) CreateNotification(channel string, filters, trigger, config interface{}, status string) (*Notification, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) CreateNotification(ctx context.Context, channel string, filters, trigger, config interface{}, status string) (*Notification, error) {
 	u := c.BaseURL + pathNotificationCreate
 	u = strings.ReplaceAll(u, "{channel}", channel)
 	l := log.With().
@@ -51,6 +50,7 @@ func (c *RollbarAPIClient) CreateNotification(channel string, filters, trigger,
 	l.Debug().Msg("Creating new notification")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetBody([]map[string]interface{}{{"filters": filters, "trigger": trigger, "config": config, "status": status}}).
 		SetResult(notificationsResponse{}).
 		SetError(ErrorResult{}).
@@ -71,9 +71,7 @@ func (c *RollbarAPIClient) CreateNotification(channel string, filters, trigger,
 }
 
 // UpdateNotification updates a Rollbar notification.
-func (c *RollbarAPIClient) UpdateNotification(notificationID int, channel string, filters, trigger, config interface{}, status string) (*Notification, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) UpdateNotification(ctx context.Context, notificationID int, channel string, filters, trigger, config interface{}, status string) (*Notification, error) {
 	u := c.BaseURL + pathNotificationReadOrDeleteOrUpdate
 	l := log.With().
 		Str("channel", channel).
@@ -81,6 +79,7 @@ func (c *RollbarAPIClient) UpdateNotification(notificationID int, channel string
 	l.Debug().Msg("Updating notification")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetBody(map[string]interface{}{"filters": filters, "trigger": trigger, "config": config, "status": status}).
 		SetResult(notificationResponse{}).
 		SetError(ErrorResult{}).
@@ -106,9 +105,7 @@ func (c *RollbarAPIClient) UpdateNotification(notificationID int, channel string
 
 // ReadNotification reads a Rollbar notification from the API. If no matching notification is found,
 // returns error ErrNotFound.
-func (c *RollbarAPIClient) ReadNotification(notificationID int, channel string) (*Notification, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ReadNotification(ctx context.Context, notificationID int, channel string) (*Notification, error) {
 	u := c.BaseURL + pathNotificationReadOrDeleteOrUpdate
 
 	l := log.With().
@@ -117,6 +114,7 @@ func (c *RollbarAPIClient) ReadNotification(notificationID int, channel string)
 	l.Debug().Msg("Reading notification from API")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetResult(notificationResponse{}).
 		SetError(ErrorResult{}).
 		SetPathParams(map[string]string{
@@ -146,9 +144,7 @@ func (c *RollbarAPIClient) ReadNotification(notificationID int, channel string)
 
 // DeleteNotification deletes a Rollbar notification. If no matching notification is found,
 // returns error ErrNotFound.
-func (c *RollbarAPIClient) DeleteNotification(notificationID int, channel string) error {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) DeleteNotification(ctx context.Context, notificationID int, channel string) error {
 	u := c.BaseURL + pathNotificationReadOrDeleteOrUpdate
 	l := log.With().
 		Int("notificationID", notificationID).
@@ -156,6 +152,7 @@ func (c *RollbarAPIClient) DeleteNotification(notificationID int, channel string
 	l.Debug().Msg("Deleting notification")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetError(ErrorResult{}).
 		SetPathParams(map[string]string{
 			"notificationID": strconv.Itoa(notificationID),
@@ -175,39 +172,42 @@ func (c *RollbarAPIClient) DeleteNotification(notificationID int, channel string
 	return nil
 }
 
-func (c *RollbarAPIClient) ListNotifications(channel string) ([]Notification, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ListNotifications(ctx context.Context, channel string) ([]Notification, error) {
 	u := c.BaseURL + pathNotificationCreate
 
 	l := log.With().
 		Logger()
 	l.Debug().Msg("Reading notifications from API")
 
-	resp, err := c.Resty.R().
-		SetResult(notificationsResponse{}).
-		SetError(ErrorResult{}).
-		SetPathParams(map[string]string{
-			"channel": channel,
-		}).
-		Get(u)
-
-	if err != nil {
-		l.Err(err).Msg(resp.Status())
-		return nil, err
-	}
-	err = errorFromResponse(resp)
+	notifications, err := listAll(ctx, func(ctx context.Context, page, perPage int) ([]Notification, error) {
+		resp, err := c.Resty.R().
+			SetContext(ctx).
+			SetResult(notificationsResponse{}).
+			SetError(ErrorResult{}).
+			SetPathParams(map[string]string{
+				"channel": channel,
+			}).
+			SetQueryParams(pageParams(page, perPage)).
+			Get(u)
+		if err != nil {
+			return nil, err
+		}
+		err = errorFromResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		nr := resp.Result().(*notificationsResponse)
+		if nr.Err != 0 {
+			return nil, ErrNotFound
+		}
+		return nr.Result, nil
+	}, func(n Notification) int { return n.ID })
 	if err != nil {
 		l.Err(err).Send()
 		return nil, err
 	}
-	nr := resp.Result().(*notificationsResponse)
-	if nr.Err != 0 {
-		l.Warn().Msg("Notification not found")
-		return nil, ErrNotFound
-	}
 	l.Debug().Msg("Notification successfully read")
-	return nr.Result, nil
+	return notifications, nil
 }
 
 type notificationResponse struct {
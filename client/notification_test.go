@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -54,7 +55,7 @@ func (s *Suite) TestCreateNotification() {
 	}
 
 	httpmock.RegisterResponder("POST", u, r)
-	notification, err := s.client.CreateNotification(channel, filters, trigger, config, status)
+	notification, err := s.client.CreateNotification(context.Background(), channel, filters, trigger, config, status)
 	s.Nil(err)
 	s.Equal(trigger, notification.Trigger)
 	s.Equal(action, notification.Action)
@@ -62,7 +63,7 @@ func (s *Suite) TestCreateNotification() {
 	s.Equal(status, notification.Status)
 
 	s.checkServerErrors("POST", u, func() error {
-		_, err = s.client.CreateNotification(channel, filters, trigger, config, status)
+		_, err = s.client.CreateNotification(context.Background(), channel, filters, trigger, config, status)
 		return err
 	})
 }
@@ -92,7 +93,7 @@ func (s *Suite) TestUpdateNotification() {
 	}
 
 	httpmock.RegisterResponder("PUT", u, r)
-	notification, err := s.client.UpdateNotification(id, channel, filters, trigger, config, status)
+	notification, err := s.client.UpdateNotification(context.Background(), id, channel, filters, trigger, config, status)
 	s.Nil(err)
 	s.Equal(trigger, notification.Trigger)
 	s.Equal(action, notification.Action)
@@ -100,7 +101,7 @@ func (s *Suite) TestUpdateNotification() {
 	s.Equal(status, notification.Status)
 
 	s.checkServerErrors("PUT", u, func() error {
-		_, err = s.client.UpdateNotification(id, channel, filters, trigger, config, status)
+		_, err = s.client.UpdateNotification(context.Background(), id, channel, filters, trigger, config, status)
 		return err
 	})
 }
@@ -117,21 +118,21 @@ func (s *Suite) TestReadNotification() {
 	// Success
 	r := responderFromFixture("notification/read.json", http.StatusOK)
 	httpmock.RegisterResponder("GET", u, r)
-	n, err := s.client.ReadNotification(id, channel)
+	n, err := s.client.ReadNotification(context.Background(), id, channel)
 	s.Nil(err)
 	s.Equal("new_item", n.Trigger)
 	s.Equal("disabled", n.Status)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ReadNotification(id, channel)
+		_, err := s.client.ReadNotification(context.Background(), id, channel)
 		return err
 	})
 
 	// Try to read a deleted notification
 	r = responderFromFixture("project/read_deleted.json", http.StatusOK)
 	httpmock.RegisterResponder("GET", u, r)
-	n, err = s.client.ReadNotification(id, channel)
-	s.Equal(ErrNotFound, err)
+	n, err = s.client.ReadNotification(context.Background(), id, channel)
+	s.ErrorIs(err, ErrNotFound)
 	s.Nil(n)
 }
 
@@ -146,10 +147,10 @@ func (s *Suite) TestDeleteNotification() {
 	// Success
 	r := responderFromFixture("project/delete.json", http.StatusOK)
 	httpmock.RegisterResponder("DELETE", u, r)
-	err := s.client.DeleteNotification(id, channel)
+	err := s.client.DeleteNotification(context.Background(), id, channel)
 	s.Nil(err)
 
 	s.checkServerErrors("DELETE", u, func() error {
-		return s.client.DeleteNotification(id, channel)
+		return s.client.DeleteNotification(context.Background(), id, channel)
 	})
 }
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"context"
+	"strconv"
+)
+
+// pageSize is the number of items requested per page when listing a
+// collection from the Rollbar API.  Tests lower it.
+var pageSize = 100
+
+// pageFetcher fetches one page of a collection from the Rollbar API.  Pages are
+// numbered from 1.
+type pageFetcher[T any] func(ctx context.Context, page, perPage int) ([]T, error)
+
+// pageParams returns the query parameters requesting a page of a collection.
+func pageParams(page, perPage int) map[string]string {
+	return map[string]string{
+		"page":     strconv.Itoa(page),
+		"per_page": strconv.Itoa(perPage),
+	}
+}
+
+// pager iterates over the pages of a collection from the Rollbar API.  Not all
+// endpoints honor `page` and `per_page`, so items are deduplicated by key, and
+// iteration stops at the first page that holds no item not already seen.  A
+// page shorter than requested is not taken as the last, since the API may cap
+// the page size below `per_page`.
+type pager[T any, K comparable] struct {
+	fetch   pageFetcher[T]
+	key     func(T) K
+	page    int
+	perPage int
+	seen    map[K]bool
+	done    bool
+}
+
+// newPager returns a pager over the collection fetched by fetch, whose items
+// are identified by key.
+func newPager[T any, K comparable](fetch pageFetcher[T], key func(T) K) *pager[T, K] {
+	return &pager[T, K]{
+		fetch:   fetch,
+		key:     key,
+		perPage: pageSize,
+		seen:    make(map[K]bool),
+	}
+}
+
+// Next returns the items on the next page.  It returns false once the
+// collection is exhausted or an error occurs.
+func (p *pager[T, K]) Next(ctx context.Context) ([]T, bool, error) {
+	if p.done {
+		return nil, false, nil
+	}
+	p.page++
+	raw, err := p.fetch(ctx, p.page, p.perPage)
+	if err != nil {
+		p.done = true
+		return nil, false, err
+	}
+	items := make([]T, 0, len(raw))
+	for _, item := range raw {
+		k := p.key(item)
+		if !p.seen[k] {
+			p.seen[k] = true
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		p.done = true
+		return nil, false, nil
+	}
+	return items, true, nil
+}
+
+// listAll returns every item in the collection fetched by fetch, whose items
+// are identified by key.
+func listAll[T any, K comparable](ctx context.Context, fetch pageFetcher[T], key func(T) K) ([]T, error) {
+	var all []T
+	p := newPager(fetch, key)
+	for {
+		items, ok, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return all, nil
+		}
+		all = append(all, items...)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// fakePages returns a pageFetcher serving items from pages, and a pointer to
+// the number of pages fetched.
+func fakePages(pages [][]int) (pageFetcher[int], *int) {
+	var calls int
+	return func(_ context.Context, page, perPage int) ([]int, error) {
+		calls++
+		if page > len(pages) {
+			return nil, nil
+		}
+		return pages[page-1], nil
+	}, &calls
+}
+
+func intKey(i int) int { return i }
+
+// TestListAll checks that listAll fetches every page of a collection, and
+// stops once the collection is exhausted.
+func (s *Suite) TestListAll() {
+	defer func(n int) { pageSize = n }(pageSize)
+	pageSize = 2
+
+	// Several pages, ending with an empty page
+	fetch, calls := fakePages([][]int{{1, 2}, {3, 4}, {5}})
+	items, err := listAll(context.Background(), fetch, intKey)
+	s.Nil(err)
+	s.Equal([]int{1, 2, 3, 4, 5}, items)
+	s.Equal(4, *calls)
+
+	// Endpoint caps the page size below per_page
+	fetch, calls = fakePages([][]int{{1}, {2}, {3}})
+	items, err = listAll(context.Background(), fetch, intKey)
+	s.Nil(err)
+	s.Equal([]int{1, 2, 3}, items)
+	s.Equal(4, *calls)
+
+	// Endpoint ignores pagination, returning the whole collection every time
+	fetch, calls = fakePages([][]int{{1, 2, 3}, {1, 2, 3}, {1, 2, 3}})
+	items, err = listAll(context.Background(), fetch, intKey)
+	s.Nil(err)
+	s.Equal([]int{1, 2, 3}, items)
+	s.Equal(2, *calls)
+
+	// Empty collection
+	fetch, calls = fakePages(nil)
+	items, err = listAll(context.Background(), fetch, intKey)
+	s.Nil(err)
+	s.Empty(items)
+	s.Equal(1, *calls)
+
+	// Error
+	errFetch := errors.New("fetch failed")
+	items, err = listAll(context.Background(), func(_ context.Context, page, _ int) ([]int, error) {
+		if page == 2 {
+			return nil, errFetch
+		}
+		return []int{2*page - 1, 2 * page}, nil
+	}, intKey)
+	s.ErrorIs(err, errFetch)
+	s.Nil(items)
+}
+
+// TestListUsersPaginated checks that ListUsers requests every page of users.
+func (s *Suite) TestListUsersPaginated() {
+	defer func(n int) { pageSize = n }(pageSize)
+	pageSize = 2
+	u := s.client.BaseURL + pathUsers
+	for page, users := range [][]User{
+		{{ID: 1, Email: "a@example.com"}, {ID: 2, Email: "b@example.com"}},
+		{{ID: 3, Email: "c@example.com"}},
+		{},
+	} {
+		var resp userListResponse
+		resp.Result.Users = users
+		r := httpmock.NewJsonResponderOrPanic(http.StatusOK, resp)
+		httpmock.RegisterResponderWithQuery("GET", u, map[string]string{
+			"page":     strconv.Itoa(page + 1),
+			"per_page": strconv.Itoa(pageSize),
+		}, r)
+	}
+
+	users, err := s.client.ListTestUsers(context.Background())
+	s.Nil(err)
+	s.Len(users, 3)
+	s.Equal(3, users[2].ID)
+}
@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/rs/zerolog/log"
@@ -76,47 +77,49 @@ type Project struct {
 */
 
 // ListProjects lists all Rollbar projects.
-func (c *RollbarAPIClient) ListProjects() ([]Project, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ListProjects(ctx context.Context) ([]Project, error) {
 	u := c.BaseURL + pathProjectList
 
-	resp, err := c.Resty.R().
-		SetResult(projectListResponse{}).
-		SetError(ErrorResult{}).
-		Get(u)
-	if err != nil {
-		log.Err(err).Send()
-		return nil, err
-	}
-	err = errorFromResponse(resp)
+	projects, err := listAll(ctx, func(ctx context.Context, page, perPage int) ([]Project, error) {
+		resp, err := c.Resty.R().
+			SetContext(ctx).
+			SetResult(projectListResponse{}).
+			SetError(ErrorResult{}).
+			SetQueryParams(pageParams(page, perPage)).
+			Get(u)
+		if err != nil {
+			return nil, err
+		}
+		err = errorFromResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Result().(*projectListResponse).Result, nil
+	}, func(p Project) int { return p.ID })
 	if err != nil {
 		log.Err(err).Send()
 		return nil, err
 	}
 
-	lpr := resp.Result().(*projectListResponse)
 	// FIXME: After deleting a project through the API, it still shows up in
 	//  the list of projects returned by the API - only with its name set to
 	//  nil. This seemingly undesirable behavior should be fixed on the API
 	//  side. We work around it by removing any result with an empty name.
 	cleaned := make([]Project, 0)
-	for _, proj := range lpr.Result {
+	for _, proj := range projects {
 		if proj.Name != "" {
 			cleaned = append(cleaned, proj)
 		}
 	}
 	log.Debug().
-		Int("raw_projects", len(lpr.Result)).
+		Int("raw_projects", len(projects)).
 		Int("cleaned_projects", len(cleaned)).
 		Msg("Successfully listed projects")
 	return cleaned, nil
 }
 
 // CreateProject creates a new Rollbar project.
-func (c *RollbarAPIClient) CreateProject(name string) (*Project, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) CreateProject(ctx context.Context, name string) (*Project, error) {
 	u := c.BaseURL + pathProjectCreate
 	l := log.With().
 		Str("name", name).
@@ -124,6 +127,7 @@ func (c *RollbarAPIClient) CreateProject(name string) (*Project, error) {
 	l.Debug().Msg("Creating new project")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetBody(map[string]interface{}{"name": name}).
 		SetResult(projectResponse{}).
 		SetError(ErrorResult{}).
@@ -145,9 +149,7 @@ func (c *RollbarAPIClient) CreateProject(name string) (*Project, error) {
 
 // ReadProject a Rollbar project from the API. If no matching project is found,
 // returns error ErrNotFound.
-func (c *RollbarAPIClient) ReadProject(projectID int) (*Project, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ReadProject(ctx context.Context, projectID int) (*Project, error) {
 	u := c.BaseURL + pathProjectRead
 
 	l := log.With().
@@ -156,6 +158,7 @@ func (c *RollbarAPIClient) ReadProject(projectID int) (*Project, error) {
 	l.Debug().Msg("Reading project from API")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetResult(projectResponse{}).
 		SetError(ErrorResult{}).
 		SetPathParams(map[string]string{
@@ -185,9 +188,7 @@ func (c *RollbarAPIClient) ReadProject(projectID int) (*Project, error) {
 
 // DeleteProject deletes a Rollbar project. If no matching project is found,
 // returns error ErrNotFound.
-func (c *RollbarAPIClient) DeleteProject(projectID int) error {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) DeleteProject(ctx context.Context, projectID int) error {
 	u := c.BaseURL + pathProjectDelete
 	l := log.With().
 		Int("projectID", projectID).
@@ -195,6 +196,7 @@ func (c *RollbarAPIClient) DeleteProject(projectID int) error {
 	l.Debug().Msg("Deleting project")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetError(ErrorResult{}).
 		SetPathParams(map[string]string{
 			"projectID": strconv.Itoa(projectID),
@@ -216,15 +218,14 @@ func (c *RollbarAPIClient) DeleteProject(projectID int) error {
 // FindProjectTeamIDs finds IDs of all teams assigned to the project. Caution:
 // this is a potentially slow operation that makes multiple calls to the API.
 // https://github.com/rollbar/terraform-provider-rollbar/issues/104
-func (c *RollbarAPIClient) FindProjectTeamIDs(projectID int) ([]int, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) FindProjectTeamIDs(ctx context.Context, projectID int) ([]int, error) {
 	l := log.With().Int("project_id", projectID).Logger()
 	l.Debug().Msg("Finding teams assigned to project")
 	var projectTeamIDs []int
 
 	u := c.BaseURL + pathProjectTeams
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetResult(teamProjectListResponse{}).
 		SetError(ErrorResult{}).
 		SetQueryParams(map[string]string{
@@ -260,7 +261,7 @@ func (c *RollbarAPIClient) FindProjectTeamIDs(projectID int) ([]int, error) {
 // and removing teams as necessary. Caution: this is a potentially slow
 // operation that makes multiple calls to the API.
 // https://github.com/rollbar/terraform-provider-rollbar/issues/104
-func (c *RollbarAPIClient) UpdateProjectTeams(projectID int, teamIDs []int) error {
+func (c *RollbarAPIClient) UpdateProjectTeams(ctx context.Context, projectID int, teamIDs []int) error {
 	l := log.With().
 		Int("project_id", projectID).
 		Ints("team_ids", teamIDs).
@@ -269,7 +270,7 @@ func (c *RollbarAPIClient) UpdateProjectTeams(projectID int, teamIDs []int) erro
 
 	// Compute which teams to assign and to remove
 	var assignTeamIDs, removeTeamIDs []int
-	currentTeamIDs, err := c.FindProjectTeamIDs(projectID) // Potential slowness is here
+	currentTeamIDs, err := c.FindProjectTeamIDs(ctx, projectID) // Potential slowness is here
 	if err != nil {
 		l.Err(err).Send()
 		return err
@@ -298,14 +299,14 @@ func (c *RollbarAPIClient) UpdateProjectTeams(projectID int, teamIDs []int) erro
 		Msg("Teams to assign and remove")
 
 	for _, teamID := range assignTeamIDs {
-		err = c.AssignTeamToProject(teamID, projectID)
+		err = c.AssignTeamToProject(ctx, teamID, projectID)
 		if err != nil {
 			l.Err(err).Send()
 			return err
 		}
 	}
 	for _, teamID := range removeTeamIDs {
-		err = c.RemoveTeamFromProject(teamID, projectID)
+		err = c.RemoveTeamFromProject(ctx, teamID, projectID)
 		if err != nil {
 			l.Err(err).Send()
 			return err
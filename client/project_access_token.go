@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -122,15 +123,16 @@ func (args *ProjectAccessTokenCreateArgs) sanityCheck() error {
 }
 
 // ProjectAccessTokenUpdateArgs encapsulates the required and optional arguments
-// for creating a Rollbar project access token.
-//
-// Curently not all attributes can be updated.
-// https://github.com/rollbar/terraform-provider-rollbar/issues/41
+// for updating a Rollbar project access token.  Name, Scopes and Status are
+// left unchanged if empty; the rate limit is always set.
 type ProjectAccessTokenUpdateArgs struct {
-	ProjectID            int    `json:"-"`
-	AccessToken          string `json:"-"`
-	RateLimitWindowSize  int    `json:"rate_limit_window_size"`
-	RateLimitWindowCount int    `json:"rate_limit_window_count"`
+	ProjectID            int     `json:"-"`
+	AccessToken          string  `json:"-"`
+	Name                 string  `json:"name,omitempty"`
+	Scopes               []Scope `json:"scopes,omitempty"`
+	Status               Status  `json:"status,omitempty"`
+	RateLimitWindowSize  int     `json:"rate_limit_window_size"`
+	RateLimitWindowCount int     `json:"rate_limit_window_count"`
 }
 
 // sanityCheck checks that the arguments are sane.
@@ -147,6 +149,22 @@ func (args *ProjectAccessTokenUpdateArgs) sanityCheck() error {
 		err := fmt.Errorf("access token cannot be blank")
 		errors = append(errors, err)
 	}
+	for _, s := range args.Scopes {
+		switch s {
+		case ScopeRead, ScopeWrite, ScopePostClientItem, ScopePostServerItem:
+			// Passed sanity check
+		default:
+			err := fmt.Errorf("invalid scope")
+			errors = append(errors, err)
+		}
+	}
+	switch args.Status {
+	case "", StatusEnabled, StatusDisabled:
+		// Passed sanity check
+	default:
+		err := fmt.Errorf("invalid status")
+		errors = append(errors, err)
+	}
 	if args.RateLimitWindowCount < 0 {
 		err := fmt.Errorf("rate limit window count must be zero or greater")
 		errors = append(errors, err)
@@ -166,39 +184,43 @@ func (args *ProjectAccessTokenUpdateArgs) sanityCheck() error {
 
 // ListProjectAccessTokens lists the Rollbar project access tokens for the
 // specified Rollbar project.
-func (c *RollbarAPIClient) ListProjectAccessTokens(projectID int) ([]ProjectAccessToken, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ListProjectAccessTokens(ctx context.Context, projectID int) ([]ProjectAccessToken, error) {
 	l := log.With().
 		Int("projectID", projectID).
 		Logger()
 	l.Debug().Msg("Listing project access tokens")
 
 	u := c.BaseURL + pathProjectTokens
-	resp, err := c.Resty.R().
-		SetResult(patListResponse{}).
-		SetError(ErrorResult{}).
-		SetPathParams(map[string]string{
-			"projectID": strconv.Itoa(projectID),
-		}).
-		Get(u)
-	if err != nil {
-		l.Err(err).Send()
-		return nil, err
-	}
-	err = errorFromResponse(resp)
+	pats, err := listAll(ctx, func(ctx context.Context, page, perPage int) ([]ProjectAccessToken, error) {
+		resp, err := c.Resty.R().
+			SetContext(ctx).
+			SetResult(patListResponse{}).
+			SetError(ErrorResult{}).
+			SetPathParams(map[string]string{
+				"projectID": strconv.Itoa(projectID),
+			}).
+			SetQueryParams(pageParams(page, perPage)).
+			Get(u)
+		if err != nil {
+			return nil, err
+		}
+		err = errorFromResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Result().(*patListResponse).Result, nil
+	}, func(pat ProjectAccessToken) string { return pat.AccessToken })
 	if err != nil {
 		l.Err(err).Send()
 		return nil, err
 	}
-	pats := resp.Result().(*patListResponse).Result
 	return pats, nil
 }
 
 // ReadProjectAccessToken reads a Rollbar project access token from the API.  It
 // returns the first token that matches `name`. If no matching token is found,
 // returns error ErrNotFound.
-func (c *RollbarAPIClient) ReadProjectAccessToken(projectID int, token string) (ProjectAccessToken, error) {
+func (c *RollbarAPIClient) ReadProjectAccessToken(ctx context.Context, projectID int, token string) (ProjectAccessToken, error) {
 	l := log.With().
 		Int("projectID", projectID).
 		Str("token", token).
@@ -206,7 +228,7 @@ func (c *RollbarAPIClient) ReadProjectAccessToken(projectID int, token string) (
 	l.Debug().Msg("Reading project access token")
 
 	var pat ProjectAccessToken
-	tokens, err := c.ListProjectAccessTokens(projectID)
+	tokens, err := c.ListProjectAccessTokens(ctx, projectID)
 	if err != nil {
 		l.Err(err).
 			Msg("Error listing project access tokens")
@@ -229,7 +251,7 @@ func (c *RollbarAPIClient) ReadProjectAccessToken(projectID int, token string) (
 // ReadProjectAccessTokenByName reads a Rollbar project access token from the
 // API.  It returns the first token that matches `name`. If no matching token is
 // found, returns error ErrNotFound.
-func (c *RollbarAPIClient) ReadProjectAccessTokenByName(projectID int, name string) (ProjectAccessToken, error) {
+func (c *RollbarAPIClient) ReadProjectAccessTokenByName(ctx context.Context, projectID int, name string) (ProjectAccessToken, error) {
 	l := log.With().
 		Int("projectID", projectID).
 		Str("name", name).
@@ -237,7 +259,7 @@ func (c *RollbarAPIClient) ReadProjectAccessTokenByName(projectID int, name stri
 	l.Debug().Msg("Reading project access token")
 
 	var pat ProjectAccessToken
-	tokens, err := c.ListProjectAccessTokens(projectID)
+	tokens, err := c.ListProjectAccessTokens(ctx, projectID)
 	if err != nil {
 		l.Err(err).
 			Msg("Error reading project access token")
@@ -256,9 +278,7 @@ func (c *RollbarAPIClient) ReadProjectAccessTokenByName(projectID int, name stri
 }
 
 // DeleteProjectAccessToken deletes a Rollbar project access token.
-func (c *RollbarAPIClient) DeleteProjectAccessToken(projectID int, token string) error {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) DeleteProjectAccessToken(ctx context.Context, projectID int, token string) error {
 	l := log.With().
 		Int("projectID", projectID).
 		Str("token", token).
@@ -267,6 +287,7 @@ func (c *RollbarAPIClient) DeleteProjectAccessToken(projectID int, token string)
 
 	u := c.BaseURL + pathProjectToken
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"projectID":   strconv.Itoa(projectID),
 			"accessToken": token,
@@ -286,9 +307,7 @@ func (c *RollbarAPIClient) DeleteProjectAccessToken(projectID int, token string)
 }
 
 // CreateProjectAccessToken creates a Rollbar project access token.
-func (c *RollbarAPIClient) CreateProjectAccessToken(args ProjectAccessTokenCreateArgs) (ProjectAccessToken, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) CreateProjectAccessToken(ctx context.Context, args ProjectAccessTokenCreateArgs) (ProjectAccessToken, error) {
 	l := log.With().
 		Interface("args", args).
 		Logger()
@@ -303,6 +322,7 @@ func (c *RollbarAPIClient) CreateProjectAccessToken(args ProjectAccessTokenCreat
 
 	u := c.BaseURL + pathProjectTokens
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"projectID": strconv.Itoa(args.ProjectID),
 		}).
@@ -328,9 +348,7 @@ func (c *RollbarAPIClient) CreateProjectAccessToken(args ProjectAccessTokenCreat
 }
 
 // UpdateProjectAccessToken updates a Rollbar project access token.
-func (c *RollbarAPIClient) UpdateProjectAccessToken(args ProjectAccessTokenUpdateArgs) error {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) UpdateProjectAccessToken(ctx context.Context, args ProjectAccessTokenUpdateArgs) error {
 	l := log.With().
 		Interface("args", args).
 		Logger()
@@ -344,6 +362,7 @@ func (c *RollbarAPIClient) UpdateProjectAccessToken(args ProjectAccessTokenUpdat
 
 	u := c.BaseURL + pathProjectToken
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"projectID":   strconv.Itoa(args.ProjectID),
 			"accessToken": args.AccessToken,
@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/jarcoal/httpmock"
 	"github.com/rs/zerolog/log"
@@ -103,12 +104,12 @@ func (s *Suite) TestListProjectAccessTokens() {
 			Status: "enabled",
 		},
 	}
-	actual, err := s.client.ListProjectAccessTokens(projectID)
+	actual, err := s.client.ListProjectAccessTokens(context.Background(), projectID)
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	testFunc := func() error {
-		_, err = s.client.ListProjectAccessTokens(projectID)
+		_, err = s.client.ListProjectAccessTokens(context.Background(), projectID)
 		return err
 	}
 	s.checkServerErrors("GET", u, testFunc)
@@ -141,16 +142,16 @@ func (s *Suite) TestReadProjectAccessToken() {
 		},
 		Status: "enabled",
 	}
-	actual, err := s.client.ReadProjectAccessToken(projectID, expected.AccessToken)
+	actual, err := s.client.ReadProjectAccessToken(context.Background(), projectID, expected.AccessToken)
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	// PAT does not exist
-	_, err = s.client.ReadProjectAccessToken(projectID, "does-not-exist")
-	s.Equal(ErrNotFound, err)
+	_, err = s.client.ReadProjectAccessToken(context.Background(), projectID, "does-not-exist")
+	s.ErrorIs(err, ErrNotFound)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err = s.client.ReadProjectAccessToken(projectID, "does-not-exist")
+		_, err = s.client.ReadProjectAccessToken(context.Background(), projectID, "does-not-exist")
 		return err
 	})
 }
@@ -181,16 +182,16 @@ func (s *Suite) TestReadProjectAccessTokenByName() {
 		},
 		Status: "enabled",
 	}
-	actual, err := s.client.ReadProjectAccessTokenByName(projectID, expected.Name)
+	actual, err := s.client.ReadProjectAccessTokenByName(context.Background(), projectID, expected.Name)
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	// PAT with name does not exist
-	_, err = s.client.ReadProjectAccessTokenByName(projectID, "this-name-does-not-exist")
-	s.Equal(ErrNotFound, err)
+	_, err = s.client.ReadProjectAccessTokenByName(context.Background(), projectID, "this-name-does-not-exist")
+	s.ErrorIs(err, ErrNotFound)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ReadProjectAccessTokenByName(projectID, expected.Name)
+		_, err := s.client.ReadProjectAccessTokenByName(context.Background(), projectID, expected.Name)
 		return err
 	})
 
@@ -207,11 +208,11 @@ func (s *Suite) TestDeleteProjectAccessToken() {
 	r := responderFromFixture("project_access_token/delete.json", http.StatusOK)
 	httpmock.RegisterResponder("DELETE", u, r)
 
-	err := s.client.DeleteProjectAccessToken(projectID, token)
+	err := s.client.DeleteProjectAccessToken(context.Background(), projectID, token)
 	s.Nil(err)
 
 	s.checkServerErrors("DELETE", u, func() error {
-		err := s.client.DeleteProjectAccessToken(projectID, token)
+		err := s.client.DeleteProjectAccessToken(context.Background(), projectID, token)
 		return err
 	})
 }
@@ -247,47 +248,47 @@ func (s *Suite) TestCreateProjectAccessToken() {
 	// Invalid project ID
 	badArgs := args
 	badArgs.ProjectID = 0
-	_, err := s.client.CreateProjectAccessToken(badArgs)
+	_, err := s.client.CreateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	badArgs = args
 	badArgs.ProjectID = -234
-	_, err = s.client.CreateProjectAccessToken(badArgs)
+	_, err = s.client.CreateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	// Invalid project name
 	badArgs = args
 	badArgs.Name = ""
-	_, err = s.client.CreateProjectAccessToken(badArgs)
+	_, err = s.client.CreateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	// No scopes specified
 	badArgs = args
 	badArgs.Scopes = []Scope{}
-	_, err = s.client.CreateProjectAccessToken(badArgs)
+	_, err = s.client.CreateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	// Invalid scope
 	badArgs = args
 	derpScope := Scope("derp!")
 	badArgs.Scopes = []Scope{derpScope}
-	_, err = s.client.CreateProjectAccessToken(badArgs)
+	_, err = s.client.CreateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	// Invalid status
 	badArgs = args
 	derpStatus := Status("derp!")
 	badArgs.Status = derpStatus
-	_, err = s.client.CreateProjectAccessToken(badArgs)
+	_, err = s.client.CreateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	// Invalid rate limit window size
 	badArgs = args
 	badArgs.RateLimitWindowSize = -33
-	_, err = s.client.CreateProjectAccessToken(badArgs)
+	_, err = s.client.CreateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	// Invalid rate limit window count
 	badArgs = args
 	badArgs.RateLimitWindowCount = -54
-	_, err = s.client.CreateProjectAccessToken(badArgs)
+	_, err = s.client.CreateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 
 	// Success
-	t, err := s.client.CreateProjectAccessToken(args)
+	t, err := s.client.CreateProjectAccessToken(context.Background(), args)
 	s.Nil(err)
 	s.NotEmpty(t.AccessToken)
 	s.Equal(args.Name, t.Name)
@@ -295,7 +296,7 @@ func (s *Suite) TestCreateProjectAccessToken() {
 	s.Equal(args.ProjectID, t.ProjectID)
 
 	s.checkServerErrors("POST", u, func() error {
-		_, err = s.client.CreateProjectAccessToken(args)
+		_, err = s.client.CreateProjectAccessToken(context.Background(), args)
 		return err
 	})
 }
@@ -307,6 +308,9 @@ func (s *Suite) TestUpdateProjectAccessToken() {
 	args := ProjectAccessTokenUpdateArgs{
 		ProjectID:            projID,
 		AccessToken:          accessToken,
+		Name:                 "renamed",
+		Scopes:               []Scope{ScopeRead, ScopeWrite},
+		Status:               StatusDisabled,
 		RateLimitWindowSize:  1000,
 		RateLimitWindowCount: 2500,
 	}
@@ -323,6 +327,9 @@ func (s *Suite) TestUpdateProjectAccessToken() {
 		s.Nil(err)
 		s.Equal(args.RateLimitWindowCount, a.RateLimitWindowCount)
 		s.Equal(args.RateLimitWindowSize, a.RateLimitWindowSize)
+		s.Equal(args.Name, a.Name)
+		s.Equal(args.Scopes, a.Scopes)
+		s.Equal(args.Status, a.Status)
 		return rs, nil
 	}
 	httpmock.RegisterResponder("PATCH", u, r)
@@ -333,33 +340,43 @@ func (s *Suite) TestUpdateProjectAccessToken() {
 	// Invalid project ID
 	badArgs := args
 	badArgs.ProjectID = 0
-	err := s.client.UpdateProjectAccessToken(badArgs)
+	err := s.client.UpdateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	badArgs = args
 	badArgs.ProjectID = -234
-	err = s.client.UpdateProjectAccessToken(badArgs)
+	err = s.client.UpdateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	// Invalid access token
 	badArgs = args
 	badArgs.AccessToken = ""
-	err = s.client.UpdateProjectAccessToken(badArgs)
+	err = s.client.UpdateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	// Invalid rate limit window size
 	badArgs = args
 	badArgs.RateLimitWindowSize = -33
-	err = s.client.UpdateProjectAccessToken(badArgs)
+	err = s.client.UpdateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 	// Invalid rate limit window count
 	badArgs = args
 	badArgs.RateLimitWindowCount = -54
-	err = s.client.UpdateProjectAccessToken(badArgs)
+	err = s.client.UpdateProjectAccessToken(context.Background(), badArgs)
+	s.NotNil(err)
+	// Invalid scope
+	badArgs = args
+	badArgs.Scopes = []Scope{"admin"}
+	err = s.client.UpdateProjectAccessToken(context.Background(), badArgs)
+	s.NotNil(err)
+	// Invalid status
+	badArgs = args
+	badArgs.Status = "revoked"
+	err = s.client.UpdateProjectAccessToken(context.Background(), badArgs)
 	s.NotNil(err)
 
 	// Success
-	err = s.client.UpdateProjectAccessToken(args)
+	err = s.client.UpdateProjectAccessToken(context.Background(), args)
 	s.Nil(err)
 
 	s.checkServerErrors("PATCH", u, func() error {
-		return s.client.UpdateProjectAccessToken(args)
+		return s.client.UpdateProjectAccessToken(context.Background(), args)
 	})
 }
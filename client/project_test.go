@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"os"
@@ -62,13 +63,13 @@ func (s *Suite) TestListProjects() {
 			DateModified: 1602085340,
 		},
 	}
-	actual, err := s.client.ListProjects()
+	actual, err := s.client.ListProjects(context.Background())
 	s.Nil(err)
 	s.Len(actual, len(expected))
 	s.ElementsMatch(expected, actual)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err = s.client.ListProjects()
+		_, err = s.client.ListProjects(context.Background())
 		return err
 	})
 }
@@ -90,12 +91,12 @@ func (s *Suite) TestCreateProject() {
 		return rs, nil
 	}
 	httpmock.RegisterResponder("POST", u, r)
-	proj, err := s.client.CreateProject(name)
+	proj, err := s.client.CreateProject(context.Background(), name)
 	s.Nil(err)
 	s.Equal(name, proj.Name)
 
 	s.checkServerErrors("POST", u, func() error {
-		_, err = s.client.CreateProject(name)
+		_, err = s.client.CreateProject(context.Background(), name)
 		return err
 	})
 }
@@ -116,20 +117,20 @@ func (s *Suite) TestReadProject() {
 	// Success
 	r := responderFromFixture("project/read.json", http.StatusOK)
 	httpmock.RegisterResponder("GET", u, r)
-	actual, err := s.client.ReadProject(expected.ID)
+	actual, err := s.client.ReadProject(context.Background(), expected.ID)
 	s.Nil(err)
 	s.Equal(&expected, actual)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ReadProject(expected.ID)
+		_, err := s.client.ReadProject(context.Background(), expected.ID)
 		return err
 	})
 
 	// Try to read a deleted project
 	r = responderFromFixture("project/read_deleted.json", http.StatusOK)
 	httpmock.RegisterResponder("GET", u, r)
-	_, err = s.client.ReadProject(expected.ID)
-	s.Equal(ErrNotFound, err)
+	_, err = s.client.ReadProject(context.Background(), expected.ID)
+	s.ErrorIs(err, ErrNotFound)
 }
 
 // TestDeleteProject tests deleting a Rollbar project.
@@ -141,11 +142,11 @@ func (s *Suite) TestDeleteProject() {
 	// Success
 	r := responderFromFixture("project/delete.json", http.StatusOK)
 	httpmock.RegisterResponder("DELETE", urlDel, r)
-	err := s.client.DeleteProject(delID)
+	err := s.client.DeleteProject(context.Background(), delID)
 	s.Nil(err)
 
 	s.checkServerErrors("DELETE", urlDel, func() error {
-		return s.client.DeleteProject(delID)
+		return s.client.DeleteProject(context.Background(), delID)
 	})
 }
 
@@ -163,9 +164,16 @@ func TestUpdateProjectTeams(t *testing.T) {
 		}
 	}()
 	r.AddFilter(func(i *cassette.Interaction) error {
-		delete(i.Request.Headers, "X-Rollbar-Access-Token")
+		i.Request.URL = RedactURL(i.Request.URL)
+		i.Request.Headers = RedactHeader(i.Request.Headers)
+		i.Request.Body = RedactBody(i.Request.Body)
+		i.Response.Headers = RedactHeader(i.Response.Headers)
+		i.Response.Body = RedactBody(i.Response.Body)
 		return nil
 	})
+	r.SetMatcher(func(req *http.Request, i cassette.Request) bool {
+		return req.Method == i.Method && RedactURL(req.URL.String()) == i.URL
+	})
 
 	c := NewClient(DefaultBaseURL, os.Getenv("ROLLBAR_API_KEY"))
 	c.Resty.GetClient().Transport = r
@@ -176,38 +184,38 @@ func TestUpdateProjectTeams(t *testing.T) {
 	team1Name := prefix + "-1"
 	team2Name := prefix + "-2"
 
-	project, err := c.CreateProject(projectName)
+	project, err := c.CreateProject(context.Background(), projectName)
 	assert.Nil(t, err)
-	team0, err := c.CreateTeam(team0Name, "standard")
+	team0, err := c.CreateTeam(context.Background(), team0Name, "standard")
 	assert.Nil(t, err)
-	team1, err := c.CreateTeam(team1Name, "standard")
+	team1, err := c.CreateTeam(context.Background(), team1Name, "standard")
 	assert.Nil(t, err)
-	team2, err := c.CreateTeam(team2Name, "standard")
+	team2, err := c.CreateTeam(context.Background(), team2Name, "standard")
 	assert.Nil(t, err)
-	err = c.AssignTeamToProject(team0.ID, project.ID)
+	err = c.AssignTeamToProject(context.Background(), team0.ID, project.ID)
 	assert.Nil(t, err)
-	err = c.AssignTeamToProject(team1.ID, project.ID)
+	err = c.AssignTeamToProject(context.Background(), team1.ID, project.ID)
 	assert.Nil(t, err)
 
 	expectedTeamIDs := []int{team1.ID, team2.ID}
-	err = c.UpdateProjectTeams(project.ID, expectedTeamIDs)
+	err = c.UpdateProjectTeams(context.Background(), project.ID, expectedTeamIDs)
 	assert.Nil(t, err)
-	actualTeamIDs, err := c.FindProjectTeamIDs(project.ID)
+	actualTeamIDs, err := c.FindProjectTeamIDs(context.Background(), project.ID)
 	assert.Nil(t, err)
 	assert.ElementsMatch(t, expectedTeamIDs, actualTeamIDs)
 
 	// Bad project ID
-	err = c.UpdateProjectTeams(0, expectedTeamIDs)
+	err = c.UpdateProjectTeams(context.Background(), 0, expectedTeamIDs)
 	assert.NotNil(t, err)
 	// Bad team ID
-	err = c.UpdateProjectTeams(project.ID, []int{0})
+	err = c.UpdateProjectTeams(context.Background(), project.ID, []int{0})
 	assert.NotNil(t, err)
 
 	// Cleanup
 	for _, teamID := range []int{team0.ID, team1.ID, team2.ID} {
-		err = c.DeleteTeam(teamID)
+		err = c.DeleteTeam(context.Background(), teamID)
 		assert.Nil(t, err)
 	}
-	err = c.DeleteProject(project.ID)
+	err = c.DeleteProject(context.Background(), project.ID)
 	assert.Nil(t, err)
 }
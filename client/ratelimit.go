@@ -0,0 +1,205 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// Rate limit headers sent by the Rollbar API on every response.
+const (
+	headerRateLimitLimit     = "X-Rate-Limit-Limit"
+	headerRateLimitRemaining = "X-Rate-Limit-Remaining"
+	headerRateLimitReset     = "X-Rate-Limit-Reset"
+	headerRetryAfter         = "Retry-After"
+)
+
+// rateLimitLowWater is the fraction of the rate limit below which the client
+// starts spacing out requests over the rest of the window.
+const rateLimitLowWater = 0.1
+
+// maxRateLimitWait caps how long the client will sleep waiting for a rate
+// limit window to reset.  Rollbar windows are one minute long.
+const maxRateLimitWait = 2 * time.Minute
+
+// rateLimiter tracks the API quota reported by Rollbar and delays requests so
+// the quota is not exhausted before the window resets.  It is safe for
+// concurrent use.
+type rateLimiter struct {
+	m         sync.Mutex
+	limit     int       // requests allowed per window; zero if unknown
+	remaining int       // requests left in the current window
+	reset     time.Time // end of the current window; zero if unknown
+	stats     *Stats    // records delays; may be nil
+}
+
+// beforeRequest is a Resty request middleware that waits, if necessary, before
+// letting a request go out.
+func (rl *rateLimiter) beforeRequest(_ *resty.Client, req *resty.Request) error {
+	d := rl.reserve(time.Now())
+	if d <= 0 {
+		return nil
+	}
+	rl.stats.recordWait(d)
+	log.Debug().
+		Dur("delay", d).
+		Str("URL", RedactURL(req.URL)).
+		Msg("Delaying request to stay within Rollbar API rate limit")
+	ctx := req.Context()
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve accounts for one request about to be sent at time now, and returns
+// how long the caller should wait before sending it.
+func (rl *rateLimiter) reserve(now time.Time) time.Duration {
+	rl.m.Lock()
+	defer rl.m.Unlock()
+	if rl.reset.IsZero() || !now.Before(rl.reset) {
+		return 0 // No quota information, or the window is over
+	}
+	untilReset := rl.reset.Sub(now)
+	if rl.remaining <= 0 {
+		return untilReset
+	}
+	var d time.Duration
+	if float64(rl.remaining) <= float64(rl.limit)*rateLimitLowWater {
+		// Spread the remaining requests evenly over the rest of the window
+		d = untilReset / time.Duration(rl.remaining+1)
+	}
+	rl.remaining--
+	return d
+}
+
+// afterResponse is a Resty response middleware that records the quota
+// reported in the response headers.
+func (rl *rateLimiter) afterResponse(_ *resty.Client, resp *resty.Response) error {
+	rl.update(resp.StatusCode(), resp.Header(), time.Now())
+	return nil
+}
+
+// update records the quota reported in headers h of a response with the given
+// status, received at time now.
+func (rl *rateLimiter) update(status int, h http.Header, now time.Time) {
+	rl.m.Lock()
+	defer rl.m.Unlock()
+	if limit, err := strconv.Atoi(h.Get(headerRateLimitLimit)); err == nil {
+		rl.limit = limit
+	}
+	remaining, errRemaining := strconv.Atoi(h.Get(headerRateLimitRemaining))
+	reset, errReset := strconv.ParseInt(h.Get(headerRateLimitReset), 10, 64)
+	if errRemaining == nil && errReset == nil {
+		rl.remaining = remaining
+		rl.reset = time.Unix(reset, 0)
+	}
+	if status == http.StatusTooManyRequests {
+		rl.remaining = 0
+		if d, ok := parseRetryAfter(h.Get(headerRetryAfter), now); ok {
+			rl.reset = now.Add(d)
+		}
+	}
+}
+
+// retryAfter returns a Resty RetryAfterFunc.  A rate limited response is
+// retried exactly when the API says the quota resets; anything else is
+// retried after a capped exponential backoff with jitter between minWait and
+// maxWait.  Waits are recorded in stats, which may be nil.
+func retryAfter(minWait, maxWait time.Duration, stats *Stats) resty.RetryAfterFunc {
+	return func(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+		d, ok := rateLimitWait(resp, time.Now())
+		if ok {
+			log.Warn().
+				Dur("wait", d).
+				Msg("Rollbar API rate limit exceeded, waiting for reset")
+		} else {
+			d = backoff(minWait, maxWait, resp.Request.Attempt-1)
+		}
+		stats.recordWait(d)
+		return d, nil
+	}
+}
+
+// rateLimitWait returns how long to wait before retrying a rate limited
+// response, from its Retry-After or X-Rate-Limit-Reset header.  It reports
+// false if resp was not rate limited or carries neither header.
+func rateLimitWait(resp *resty.Response, now time.Time) (time.Duration, bool) {
+	if resp.RawResponse == nil || resp.StatusCode() != http.StatusTooManyRequests {
+		return 0, false
+	}
+	d, ok := parseRetryAfter(resp.Header().Get(headerRetryAfter), now)
+	if !ok {
+		reset, err := strconv.ParseInt(resp.Header().Get(headerRateLimitReset), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		d, ok = time.Unix(reset, 0).Sub(now), true
+	}
+	if d <= 0 {
+		d = time.Nanosecond // The window has already reset; retry now
+	}
+	return d, ok
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a number
+// of seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t.Sub(now), true
+	}
+	return 0, false
+}
+
+// backoff returns a capped exponential backoff with jitter for the given
+// zero-based retry attempt.
+func backoff(minWait, maxWait time.Duration, attempt int) time.Duration {
+	temp := math.Min(float64(maxWait), float64(minWait)*math.Exp2(float64(attempt)))
+	half := time.Duration(temp / 2)
+	d := half
+	if half > 0 {
+		d += time.Duration(rand.Int63n(int64(half)))
+	}
+	if d < minWait {
+		d = minWait
+	}
+	return d
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimitServer starts a stand-in Rollbar API server that answers project
+// read requests by calling respond with the zero-based request number.  It
+// returns the server and a function reporting when each request arrived.
+func (s *Suite) rateLimitServer(respond func(n int, w http.ResponseWriter)) (*httptest.Server, func() []time.Time) {
+	var m sync.Mutex
+	var arrivals []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/1/project/1", r.URL.Path)
+		m.Lock()
+		n := len(arrivals)
+		arrivals = append(arrivals, time.Now())
+		m.Unlock()
+		respond(n, w)
+	}))
+	s.T().Cleanup(srv.Close)
+	return srv, func() []time.Time {
+		m.Lock()
+		defer m.Unlock()
+		return append([]time.Time(nil), arrivals...)
+	}
+}
+
+func writeProject(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(projectResponse{
+		Result: Project{ID: 1, Name: "foo"},
+	})
+}
+
+func writeTooManyRequests(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(ErrorResult{Err: 1, Message: "Rate limit exceeded"})
+}
+
+// TestRateLimitRetryAfter checks that a 429 response is retried after the
+// delay in its Retry-After header, not after the default backoff.
+func (s *Suite) TestRateLimitRetryAfter() {
+	srv, arrivals := s.rateLimitServer(func(n int, w http.ResponseWriter) {
+		if n == 0 {
+			w.Header().Set("Retry-After", "1")
+			writeTooManyRequests(w)
+			return
+		}
+		writeProject(w)
+	})
+	c := NewClient(srv.URL, "fakeTokenString")
+
+	project, err := c.ReadProject(context.Background(), 1)
+	s.Nil(err)
+	s.Equal("foo", project.Name)
+	a := arrivals()
+	s.Len(a, 2)
+	gap := a[1].Sub(a[0])
+	s.GreaterOrEqual(gap, time.Second)
+	s.Less(gap, 3*time.Second)
+}
+
+// TestRateLimitReset checks that a 429 response without Retry-After is
+// retried once the window named by X-Rate-Limit-Reset is over.
+func (s *Suite) TestRateLimitReset() {
+	reset := time.Now().Add(2 * time.Second).Truncate(time.Second)
+	srv, arrivals := s.rateLimitServer(func(n int, w http.ResponseWriter) {
+		if n == 0 {
+			w.Header().Set("X-Rate-Limit-Limit", "5000")
+			w.Header().Set("X-Rate-Limit-Remaining", "0")
+			w.Header().Set("X-Rate-Limit-Reset", strconv.FormatInt(reset.Unix(), 10))
+			writeTooManyRequests(w)
+			return
+		}
+		writeProject(w)
+	})
+	c := NewClient(srv.URL, "fakeTokenString")
+
+	_, err := c.ReadProject(context.Background(), 1)
+	s.Nil(err)
+	a := arrivals()
+	s.Len(a, 2)
+	s.False(a[1].Before(reset))
+	s.Less(a[1].Sub(reset), 2*time.Second)
+}
+
+// TestRateLimitExhausted checks that once the API reports no quota left, the
+// next request waits for the window to reset instead of drawing a 429.
+func (s *Suite) TestRateLimitExhausted() {
+	reset := time.Now().Add(2 * time.Second).Truncate(time.Second)
+	srv, arrivals := s.rateLimitServer(func(n int, w http.ResponseWriter) {
+		w.Header().Set("X-Rate-Limit-Limit", "5000")
+		w.Header().Set("X-Rate-Limit-Remaining", "0")
+		w.Header().Set("X-Rate-Limit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		writeProject(w)
+	})
+	c := NewClient(srv.URL, "fakeTokenString")
+
+	_, err := c.ReadProject(context.Background(), 1)
+	s.Nil(err)
+	_, err = c.ReadProject(context.Background(), 1)
+	s.Nil(err)
+	a := arrivals()
+	s.Len(a, 2)
+	s.False(a[1].Before(reset))
+
+	// Waiting for the reset honors context cancellation
+	reset = time.Now().Add(time.Minute)
+	_, err = c.ReadProject(context.Background(), 1)
+	s.Nil(err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = c.ReadProject(ctx, 1)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Len(arrivals(), 3)
+}
+
+// TestRateLimiterReserve checks that requests are spread over the rest of
+// the window once the remaining quota runs low.
+func (s *Suite) TestRateLimiterReserve() {
+	now := time.Now()
+	rl := &rateLimiter{limit: 1000, remaining: 500, reset: now.Add(time.Minute)}
+	s.Zero(rl.reserve(now))
+	s.Equal(499, rl.remaining)
+
+	rl = &rateLimiter{limit: 1000, remaining: 5, reset: now.Add(time.Minute)}
+	s.Equal(10*time.Second, rl.reserve(now))
+	s.Equal(4, rl.remaining)
+
+	// Window already over
+	rl = &rateLimiter{limit: 1000, remaining: 0, reset: now.Add(-time.Second)}
+	s.Zero(rl.reserve(now))
+}
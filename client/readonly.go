@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrReadOnly is returned, wrapped, for every request that would change
+// anything in Rollbar when the client is read-only.
+var ErrReadOnly = fmt.Errorf("client is read-only")
+
+// safeMethods are the HTTP methods a read-only client may send.
+var safeMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+}
+
+// rejectMutating is a Resty request middleware for read-only clients.  It
+// logs what a mutating request would have sent, then refuses to send it.
+// The client's retry condition does not retry requests it refuses.
+func rejectMutating(_ *resty.Client, req *resty.Request) error {
+	if safeMethods[req.Method] {
+		return nil
+	}
+	u := RedactURL(expandURL(req))
+	l := log.Warn().
+		Str("Method", req.Method).
+		Str("URL", u)
+	if req.Body != nil {
+		b, err := json.Marshal(req.Body)
+		if err == nil {
+			l = l.Str("Body", RedactBody(string(b)))
+		}
+	}
+	l.Msg("Read-only mode: not sending request")
+	return fmt.Errorf("%w: refusing to send %s %s", ErrReadOnly, req.Method, u)
+}
+
+// expandURL returns the URL req would be sent to, with path parameters and
+// query parameters filled in.  Resty only does this after the request
+// middlewares have run.
+func expandURL(req *resty.Request) string {
+	u := req.URL
+	for k, v := range req.PathParams {
+		u = strings.ReplaceAll(u, "{"+k+"}", url.PathEscape(v))
+	}
+	for k, v := range req.RawPathParams {
+		u = strings.ReplaceAll(u, "{"+k+"}", v)
+	}
+	if len(req.QueryParam) > 0 {
+		u += "?" + req.QueryParam.Encode()
+	}
+	return u
+}
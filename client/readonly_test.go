@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// TestReadOnly checks that a read-only client sends reads but refuses, and
+// logs, every mutating request without retrying it.
+func (s *Suite) TestReadOnly() {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		writeProject(w)
+	}))
+	defer srv.Close()
+	opts := DefaultOptions()
+	opts.ReadOnly = true
+	c := NewClientWithOptions(srv.URL, "fakeTokenString", opts)
+	attempts := countAttempts(c)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	orig := log.Logger
+	log.Logger = zerolog.New(&buf)
+	defer func() { log.Logger = orig }()
+
+	_, err := c.ReadProject(ctx, 1)
+	s.Nil(err)
+	_, err = c.CreateProject(ctx, "foo")
+	s.ErrorIs(err, ErrReadOnly)
+	s.Contains(err.Error(), "POST "+srv.URL+"/api/1/projects")
+	err = c.DeleteProject(ctx, 1)
+	s.ErrorIs(err, ErrReadOnly)
+	s.Contains(err.Error(), "DELETE "+srv.URL+"/api/1/project/1")
+
+	s.Equal([]string{http.MethodGet}, methods)
+	s.Equal([]int{1, 1}, *attempts, "refused requests are not retried")
+	s.Contains(buf.String(), `"Body":"{\"name\":\"foo\"}"`)
+	s.Contains(buf.String(), "Read-only mode: not sending request")
+}
+
+// TestMissingToken checks that a client that requires an API token but has
+// none refuses every request, naming the resource that made it, without contacting the API.
+func (s *Suite) TestMissingToken() {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		writeProject(w)
+	}))
+	defer srv.Close()
+	opts := DefaultOptions()
+	opts.RequireToken = true
+	c := NewClientWithOptions(srv.URL, "", opts)
+	attempts := countAttempts(c)
+	ctx := WithResource(context.Background(), "rollbar_project")
+
+	_, err := c.ReadProject(ctx, 1)
+	s.ErrorIs(err, ErrNoToken)
+	var mte *MissingTokenError
+	s.ErrorAs(err, &mte)
+	s.Equal(http.MethodGet, mte.Method)
+	s.Equal("/api/1/project/{projectID}", mte.Path)
+	s.Equal("rollbar_project", mte.Resource)
+	s.Contains(err.Error(), "for resource rollbar_project")
+	s.Zero(hits)
+	s.Equal([]int{1}, *attempts, "refused requests are not retried")
+}
+
+// countAttempts returns a pointer to the number of attempts made at each
+// failed request of c, in order.
+func countAttempts(c *RollbarAPIClient) *[]int {
+	var attempts []int
+	c.Resty.OnError(func(req *resty.Request, _ error) {
+		attempts = append(attempts, req.Attempt)
+	})
+	return &attempts
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rs/zerolog"
+)
+
+// Redacted replaces secret values in logs and recorded API interactions.
+const Redacted = "REDACTED"
+
+// redactKey is a random key with which redactSecret tells secrets apart.  It
+// changes with every process, so a redacted value cannot be used to check a
+// guess of the secret.
+var redactKey = func() []byte {
+	k := make([]byte, 32)
+	_, _ = rand.Read(k)
+	return k
+}()
+
+// redactSecret masks secret s as Redacted, followed by a short keyed hash of
+// s.  Different secrets in a body stay distinct, such as the access tokens in
+// a list of tokens, and the same secret is masked the same way throughout a
+// process.
+func redactSecret(s string) string {
+	h := hmac.New(sha256.New, redactKey)
+	h.Write([]byte(s))
+	return Redacted + "-" + hex.EncodeToString(h.Sum(nil)[:4])
+}
+
+// sensitiveHeaders are HTTP headers whose values are always secret.
+var sensitiveHeaders = []string{
+	"X-Rollbar-Access-Token",
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// sensitiveKeys are JSON object keys whose values are always secret: access
+// tokens, PagerDuty service keys and webhook URLs.  Keys are compared after
+// normalizeKey.
+var sensitiveKeys = map[string]bool{
+	"accesstoken":         true,
+	"token":               true,
+	"xrollbaraccesstoken": true,
+	"apikey":              true,
+	"projectapikey":       true,
+	"servicekey":          true,
+	"url":                 true,
+}
+
+// normalizeKey lowercases a JSON key and strips separators, so that e.g.
+// "access_token", "AccessToken" and "accessToken" all match.  Keys are
+// otherwise case sensitive in one respect: "URL", as used for the request URL
+// in log events, is not a webhook URL, so it is not normalized to "url".
+func normalizeKey(k string) string {
+	if k == "URL" {
+		return k
+	}
+	k = strings.ToLower(k)
+	return strings.NewReplacer("_", "", "-", "").Replace(k)
+}
+
+// secretPatterns match secrets embedded in free text, such as URLs, resty debug
+// output and unparsable bodies.  Each match is replaced by repl.
+var secretPatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`(/access_token/)[^/?#\s"]+`), "${1}" + Redacted},
+	{regexp.MustCompile(`([?&]access_token=)[^&#\s"]+`), "${1}" + Redacted},
+	{regexp.MustCompile(`(?i)((?:X-Rollbar-Access-Token|Authorization)\s*[:=]\s*\[?)[^\s\]]+`), "${1}" + Redacted},
+	{regexp.MustCompile(`("(?:access_token|service_key|url)"\s*:\s*)"(?:[^"\\]|\\.)*"`), `${1}"` + Redacted + `"`},
+}
+
+// RedactString masks secrets embedded in free text s.
+func RedactString(s string) string {
+	for _, p := range secretPatterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	return s
+}
+
+// RedactURL masks access tokens in the path or query of URL u.
+func RedactURL(u string) string {
+	return RedactString(u)
+}
+
+// RedactHeader returns a copy of h with the values of secret headers masked.
+func RedactHeader(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	redacted := h.Clone()
+	for _, k := range sensitiveHeaders {
+		if vs := redacted.Values(k); len(vs) > 0 {
+			redacted.Set(k, Redacted)
+		}
+	}
+	return redacted
+}
+
+// RedactBody masks secrets in an API request or response body.  A JSON body
+// has the string values of sensitive keys masked at any depth, each with
+// redactSecret; any other body is treated as free text.
+func RedactBody(body string) string {
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	err := dec.Decode(&v)
+	if err != nil || dec.More() {
+		return RedactString(body)
+	}
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	err = enc.Encode(redactValue(v))
+	if err != nil {
+		return RedactString(body)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// redactValue masks secrets in a decoded JSON value.
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, elem := range v {
+			if s, ok := elem.(string); ok && s != "" && sensitiveKeys[normalizeKey(k)] {
+				v[k] = redactSecret(s)
+				continue
+			}
+			v[k] = redactValue(elem)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = redactValue(elem)
+		}
+	case string:
+		return RedactString(v)
+	}
+	return v
+}
+
+// redactRequestLog is a Resty request log callback that masks secrets in
+// debug output.
+func redactRequestLog(rl *resty.RequestLog) error {
+	rl.Header = RedactHeader(rl.Header)
+	rl.Body = RedactBody(rl.Body)
+	return nil
+}
+
+// redactResponseLog is a Resty response log callback that masks secrets in
+// debug output.
+func redactResponseLog(rl *resty.ResponseLog) error {
+	rl.Header = RedactHeader(rl.Header)
+	rl.Body = RedactBody(rl.Body)
+	return nil
+}
+
+// RedactingWriter is a zerolog.LevelWriter that masks secrets in JSON log
+// events before passing them on to an underlying writer.
+type RedactingWriter struct {
+	w zerolog.LevelWriter
+}
+
+// NewRedactingWriter returns a RedactingWriter writing to w.
+func NewRedactingWriter(w io.Writer) *RedactingWriter {
+	lw, ok := w.(zerolog.LevelWriter)
+	if !ok {
+		lw = zerolog.MultiLevelWriter(w)
+	}
+	return &RedactingWriter{w: lw}
+}
+
+// Write implements io.Writer.
+func (rw *RedactingWriter) Write(p []byte) (int, error) {
+	return rw.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+func (rw *RedactingWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	redacted := []byte(RedactBody(string(p)))
+	if len(p) > 0 && p[len(p)-1] == '\n' && (len(redacted) == 0 || redacted[len(redacted)-1] != '\n') {
+		redacted = append(redacted, '\n')
+	}
+	_, err := rw.w.WriteLevel(level, redacted)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/rs/zerolog"
+)
+
+// TestRedactBody tests masking of secrets in JSON and free text bodies.
+func (s *Suite) TestRedactBody() {
+	body := `{"err":0,"result":[{"name":"read","access_token":"abc123","project_id":411708}],` +
+		`"settings":{"service_key":"pd-key","url":"https://hooks.example.com/secret","enabled":true}}`
+	redacted := RedactBody(body)
+	for _, secret := range []string{"abc123", "pd-key", "hooks.example.com"} {
+		s.NotContains(redacted, secret)
+	}
+	s.Contains(redacted, `"name":"read"`)
+	s.Contains(redacted, `"project_id":411708`)
+	s.Contains(redacted, `"enabled":true`)
+
+	// Secrets stay distinct
+	two := RedactBody(`[{"access_token":"abc123"},{"access_token":"def456"},{"access_token":"abc123"}]`)
+	var pats []ProjectAccessToken
+	s.Require().NoError(json.Unmarshal([]byte(two), &pats))
+	s.True(strings.HasPrefix(pats[0].AccessToken, Redacted))
+	s.NotEqual(pats[0].AccessToken, pats[1].AccessToken)
+	s.Equal(pats[0].AccessToken, pats[2].AccessToken)
+
+	text := `PATCH /api/1/project/411708/access_token/abc123?access_token=abc123 X-Rollbar-Access-Token: [abc123]`
+	s.NotContains(RedactBody(text), "abc123")
+	s.Contains(RedactBody(text), "/api/1/project/411708/access_token/"+Redacted)
+}
+
+// TestRedactHeader tests masking of secret headers.
+func (s *Suite) TestRedactHeader() {
+	h := http.Header{}
+	h.Set("X-Rollbar-Access-Token", "abc123")
+	h.Set("Content-Type", "application/json")
+	redacted := RedactHeader(h)
+	s.Equal(Redacted, redacted.Get("X-Rollbar-Access-Token"))
+	s.Equal("application/json", redacted.Get("Content-Type"))
+	s.Equal("abc123", h.Get("X-Rollbar-Access-Token")) // Original is unchanged
+}
+
+// TestRedactingWriter tests that secrets are masked in log events, and in the
+// errors returned for failed requests.
+func (s *Suite) TestRedactingWriter() {
+	var buf bytes.Buffer
+	l := zerolog.New(NewRedactingWriter(&buf))
+	pat := ProjectAccessToken{Name: "read", ProjectID: 411708, AccessToken: "abc123"}
+	l.Debug().Str("token", "abc123").Interface("pat", pat).Msg("Reading project access token")
+	s.NotContains(buf.String(), "abc123")
+	s.Contains(buf.String(), `"Name":"read"`)
+	s.True(strings.HasSuffix(buf.String(), "\n"))
+
+	u := s.client.BaseURL + "/api/1/project/411708/access_token/abc123"
+	httpmock.RegisterResponder("DELETE", u,
+		httpmock.NewStringResponder(http.StatusInternalServerError, `{"err":1,"message":"oops","access_token":"abc123"}`))
+	err := s.client.DeleteProjectAccessToken(context.Background(), 411708, "abc123")
+	s.NotNil(err)
+	s.NotContains(err.Error(), "abc123")
+	s.NotContains(err.(*APIError).Body, "abc123")
+}
@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/rs/zerolog/log"
@@ -35,9 +36,7 @@ type ServiceLink struct {
 }
 
 // CreateServiceLink creates a new Rollbar service_link.
-func (c *RollbarAPIClient) CreateServiceLink(name, template string) (*ServiceLink, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) CreateServiceLink(ctx context.Context, name, template string) (*ServiceLink, error) {
 	u := c.BaseURL + pathServiceLinkCreate
 	l := log.With().
 		Str("name", name).
@@ -45,6 +44,7 @@ func (c *RollbarAPIClient) CreateServiceLink(name, template string) (*ServiceLin
 	l.Debug().Msg("Creating new service link")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetBody(map[string]string{"name": name, "template": template}).
 		SetResult(serviceLinkResponse{}).
 		SetError(ErrorResult{}).
@@ -66,9 +66,7 @@ func (c *RollbarAPIClient) CreateServiceLink(name, template string) (*ServiceLin
 }
 
 // UpdateServiceLink updates a Rollbar service link.
-func (c *RollbarAPIClient) UpdateServiceLink(id int, name, template string) (*ServiceLink, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) UpdateServiceLink(ctx context.Context, id int, name, template string) (*ServiceLink, error) {
 	u := c.BaseURL + pathServiceLinkReadOrDeleteOrUpdate
 	l := log.With().
 		Str("name", name).
@@ -76,6 +74,7 @@ func (c *RollbarAPIClient) UpdateServiceLink(id int, name, template string) (*Se
 	l.Debug().Msg("Updating service link")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetBody(map[string]interface{}{"name": name, "template": template}).
 		SetResult(serviceLinkResponse{}).
 		SetError(ErrorResult{}).
@@ -100,9 +99,7 @@ func (c *RollbarAPIClient) UpdateServiceLink(id int, name, template string) (*Se
 
 // ReadServiceLink reads a Rollbar service link from the API. If no matching service link is found,
 // returns error ErrNotFound.
-func (c *RollbarAPIClient) ReadServiceLink(id int) (*ServiceLink, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ReadServiceLink(ctx context.Context, id int) (*ServiceLink, error) {
 	u := c.BaseURL + pathServiceLinkReadOrDeleteOrUpdate
 
 	l := log.With().
@@ -111,6 +108,7 @@ func (c *RollbarAPIClient) ReadServiceLink(id int) (*ServiceLink, error) {
 	l.Debug().Msg("Reading Service Link from API")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetResult(serviceLinkResponse{}).
 		SetError(ErrorResult{}).
 		SetPathParams(map[string]string{
@@ -139,9 +137,7 @@ func (c *RollbarAPIClient) ReadServiceLink(id int) (*ServiceLink, error) {
 
 // DeleteServiceLink deletes a Rollbar service_link. If no matching service link is found,
 // returns error ErrNotFound.
-func (c *RollbarAPIClient) DeleteServiceLink(id int) error {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) DeleteServiceLink(ctx context.Context, id int) error {
 	u := c.BaseURL + pathServiceLinkReadOrDeleteOrUpdate
 	l := log.With().
 		Int("id", id).
@@ -149,6 +145,7 @@ func (c *RollbarAPIClient) DeleteServiceLink(id int) error {
 	l.Debug().Msg("Deleting Service Link")
 
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetError(ErrorResult{}).
 		SetPathParams(map[string]string{
 			"id": strconv.Itoa(id),
@@ -167,36 +164,39 @@ func (c *RollbarAPIClient) DeleteServiceLink(id int) error {
 	return nil
 }
 
-func (c *RollbarAPIClient) ListSerivceLinks() ([]ServiceLink, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ListSerivceLinks(ctx context.Context) ([]ServiceLink, error) {
 	u := c.BaseURL + pathServiceLinkCreate
 
 	l := log.With().
 		Logger()
 	l.Debug().Msg("Reading service links from API")
 
-	resp, err := c.Resty.R().
-		SetResult(serviceLinksResponse{}).
-		SetError(ErrorResult{}).
-		Get(u)
-
-	if err != nil {
-		l.Err(err).Msg(resp.Status())
-		return nil, err
-	}
-	err = errorFromResponse(resp)
+	links, err := listAll(ctx, func(ctx context.Context, page, perPage int) ([]ServiceLink, error) {
+		resp, err := c.Resty.R().
+			SetContext(ctx).
+			SetResult(serviceLinksResponse{}).
+			SetError(ErrorResult{}).
+			SetQueryParams(pageParams(page, perPage)).
+			Get(u)
+		if err != nil {
+			return nil, err
+		}
+		err = errorFromResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		sl := resp.Result().(*serviceLinksResponse)
+		if sl.Err != 0 {
+			return nil, ErrNotFound
+		}
+		return sl.Result, nil
+	}, func(sl ServiceLink) int { return sl.ID })
 	if err != nil {
 		l.Err(err).Send()
 		return nil, err
 	}
-	sl := resp.Result().(*serviceLinksResponse)
-	if sl.Err != 0 {
-		l.Warn().Msg("Service link not found")
-		return nil, ErrNotFound
-	}
 	l.Debug().Msg("Service link successfully read")
-	return sl.Result, nil
+	return links, nil
 }
 
 type serviceLinkResponse struct {
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stats counts the requests made to the Rollbar API by one or more clients.
+// It is safe for concurrent use.  A nil *Stats records nothing.
+type Stats struct {
+	m         sync.Mutex
+	endpoints map[endpointKey]*EndpointStats
+	retries   int
+	wait      time.Duration
+}
+
+// endpointKey identifies an API endpoint by method and path template.
+type endpointKey struct {
+	method string
+	path   string
+}
+
+// EndpointStats counts the requests made to one API endpoint.
+type EndpointStats struct {
+	Method   string      `json:"method"`
+	Path     string      `json:"path"`     // Path template from paths.go
+	Requests int         `json:"requests"` // Attempts, including retries
+	Retries  int         `json:"retries"`  // Attempts after the first
+	Errors   int         `json:"errors"`   // Attempts that got no response
+	Statuses map[int]int `json:"statuses"` // Responses by HTTP status code
+}
+
+// StatsSummary is a snapshot of Stats.
+type StatsSummary struct {
+	Requests    int             `json:"requests"`
+	Retries     int             `json:"retries"`
+	Errors      int             `json:"errors"`
+	WaitSeconds float64         `json:"wait_seconds"` // Time spent in rate limit delays and retry backoff
+	Endpoints   []EndpointStats `json:"endpoints"`    // Sorted by path, then method
+}
+
+// NewStats returns an empty Stats.
+func NewStats() *Stats {
+	return &Stats{endpoints: make(map[endpointKey]*EndpointStats)}
+}
+
+// recordRequest counts one attempt at a request to the endpoint with the
+// given method and path template.  The attempt got a response with the given
+// status, or none if status is zero.
+func (s *Stats) recordRequest(method, path string, attempt, status int) {
+	if s == nil {
+		return
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	k := endpointKey{method: method, path: path}
+	e, ok := s.endpoints[k]
+	if !ok {
+		e = &EndpointStats{Method: method, Path: path, Statuses: make(map[int]int)}
+		s.endpoints[k] = e
+	}
+	e.Requests++
+	if attempt > 1 {
+		e.Retries++
+		s.retries++
+	}
+	if status == 0 {
+		e.Errors++
+	} else {
+		e.Statuses[status]++
+	}
+}
+
+// recordWait adds d to the time spent waiting before sending requests.
+func (s *Stats) recordWait(d time.Duration) {
+	if s == nil || d <= 0 {
+		return
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.wait += d
+}
+
+// Summary returns a snapshot of the counts recorded so far.
+func (s *Stats) Summary() StatsSummary {
+	var sum StatsSummary
+	if s == nil {
+		return sum
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	sum.Retries = s.retries
+	sum.WaitSeconds = s.wait.Seconds()
+	sum.Endpoints = make([]EndpointStats, 0, len(s.endpoints))
+	for _, e := range s.endpoints {
+		c := *e
+		c.Statuses = make(map[int]int, len(e.Statuses))
+		for status, n := range e.Statuses {
+			c.Statuses[status] = n
+		}
+		sum.Requests += c.Requests
+		sum.Errors += c.Errors
+		sum.Endpoints = append(sum.Endpoints, c)
+	}
+	sort.Slice(sum.Endpoints, func(i, j int) bool {
+		a, b := sum.Endpoints[i], sum.Endpoints[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+	return sum
+}
+
+// statsTransport is an http.RoundTripper that counts every request sent
+// through it.
+type statsTransport struct {
+	next  http.RoundTripper
+	stats *Stats
+}
+
+// newStatsTransport wraps next, counting requests in stats.  A nil next means
+// http.DefaultTransport.
+func newStatsTransport(next http.RoundTripper, stats *Stats) *statsTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &statsTransport{next: next, stats: stats}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route, attempt := req.URL.Path, 1
+	if info, ok := req.Context().Value(contextKeyRequestInfo).(*requestInfo); ok {
+		if info.route != "" {
+			route = info.route
+		}
+		attempt = info.attempt
+	}
+	resp, err := t.next.RoundTrip(req)
+	status := 0
+	if err == nil {
+		status = resp.StatusCode
+	}
+	t.stats.recordRequest(req.Method, route, attempt, status)
+	return resp, err
+}
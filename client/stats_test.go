@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// TestStats checks that requests are counted per endpoint and status, with
+// retries and the time spent backing off.
+func (s *Suite) TestStats() {
+	srv, _ := s.rateLimitServer(func(n int, w http.ResponseWriter) {
+		if n == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeProject(w)
+	})
+	stats := NewStats()
+	opts := DefaultOptions()
+	opts.MinBackoff = 10 * time.Millisecond
+	opts.MaxBackoff = 10 * time.Millisecond
+	opts.Stats = stats
+	c := NewClientWithOptions(srv.URL, "fakeTokenString", opts)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.ReadProject(context.Background(), 1)
+		s.Nil(err)
+	}
+
+	sum := stats.Summary()
+	s.Equal(3, sum.Requests)
+	s.Equal(1, sum.Retries)
+	s.Equal(0, sum.Errors)
+	s.GreaterOrEqual(sum.WaitSeconds, 0.01)
+	s.Equal([]EndpointStats{{
+		Method:   http.MethodGet,
+		Path:     pathProjectRead,
+		Requests: 3,
+		Retries:  1,
+		Statuses: map[int]int{
+			http.StatusInternalServerError: 1,
+			http.StatusOK:                  2,
+		},
+	}}, sum.Endpoints)
+}
+
+// TestStatsNil checks that a nil *Stats records nothing and summarizes to
+// zero.
+func (s *Suite) TestStatsNil() {
+	var stats *Stats
+	stats.recordRequest(http.MethodGet, pathProjectRead, 1, http.StatusOK)
+	stats.recordWait(time.Second)
+	s.Equal(StatsSummary{}, stats.Summary())
+}
@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -40,9 +41,7 @@ type Team struct {
 }
 
 // CreateTeam creates a new Rollbar team.
-func (c *RollbarAPIClient) CreateTeam(name, level string) (Team, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) CreateTeam(ctx context.Context, name, level string) (Team, error) {
 	var t Team
 	l := log.With().
 		Str("name", name).
@@ -58,6 +57,7 @@ func (c *RollbarAPIClient) CreateTeam(name, level string) (Team, error) {
 
 	u := c.BaseURL + pathTeamCreate
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetBody(map[string]interface{}{
 			"name":         name,
 			"access_level": level,
@@ -83,27 +83,29 @@ func (c *RollbarAPIClient) CreateTeam(name, level string) (Team, error) {
 }
 
 // ListTeams lists all Rollbar teams.
-func (c *RollbarAPIClient) ListTeams() ([]Team, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ListTeams(ctx context.Context) ([]Team, error) {
 	log.Debug().Msg("Listing all teams")
-	var teams []Team
 	u := c.BaseURL + pathTeamList
-	resp, err := c.Resty.R().
-		SetResult(teamListResponse{}).
-		SetError(ErrorResult{}).
-		Get(u)
-	if err != nil {
-		log.Err(err).Msg("Error listing teams")
-		return teams, err
-	}
-	err = errorFromResponse(resp)
+	teams, err := listAll(ctx, func(ctx context.Context, page, perPage int) ([]Team, error) {
+		resp, err := c.Resty.R().
+			SetContext(ctx).
+			SetResult(teamListResponse{}).
+			SetError(ErrorResult{}).
+			SetQueryParams(pageParams(page, perPage)).
+			Get(u)
+		if err != nil {
+			return nil, err
+		}
+		err = errorFromResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Result().(*teamListResponse).Result, nil
+	}, teamKey)
 	if err != nil {
 		log.Err(err).Msg("Error listing teams")
 		return teams, err
 	}
-	r := resp.Result().(*teamListResponse)
-	teams = r.Result
 	count := len(teams)
 	log.Debug().Int("count", count).Msg("Successfully listed teams")
 	return teams, nil
@@ -112,10 +114,10 @@ func (c *RollbarAPIClient) ListTeams() ([]Team, error) {
 // ListCustomTeams lists all custom defined teams, excluding system teams
 // "Everyone" and "Owners".
 // FIXME: This function needs a better name.
-func (c *RollbarAPIClient) ListCustomTeams() ([]Team, error) {
+func (c *RollbarAPIClient) ListCustomTeams(ctx context.Context) ([]Team, error) {
 	log.Debug().Msg("Listing custom teams")
 	var customTeams []Team
-	allTeams, err := c.ListTeams()
+	allTeams, err := c.ListTeams(ctx)
 	if err != nil {
 		log.Err(err).Msg("Error listing custom teams")
 		return customTeams, err
@@ -128,9 +130,7 @@ func (c *RollbarAPIClient) ListCustomTeams() ([]Team, error) {
 
 // ReadTeam reads a Rollbar team from the API. If no matching team is found,
 // returns error ErrNotFound.
-func (c *RollbarAPIClient) ReadTeam(id int) (Team, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ReadTeam(ctx context.Context, id int) (Team, error) {
 	var t Team
 	l := log.With().
 		Int("id", id).
@@ -145,6 +145,7 @@ func (c *RollbarAPIClient) ReadTeam(id int) (Team, error) {
 	u := c.BaseURL + pathTeamRead
 	u = strings.ReplaceAll(u, "{teamID}", strconv.Itoa(id))
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetResult(teamReadResponse{}).
 		SetError(ErrorResult{}).
 		Get(u)
@@ -168,9 +169,7 @@ func (c *RollbarAPIClient) ReadTeam(id int) (Team, error) {
 
 // DeleteTeam deletes a Rollbar team. If no matching team is found, returns
 // error ErrNotFound.
-func (c *RollbarAPIClient) DeleteTeam(id int) error {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) DeleteTeam(ctx context.Context, id int) error {
 	l := log.With().
 		Int("id", id).
 		Logger()
@@ -184,6 +183,7 @@ func (c *RollbarAPIClient) DeleteTeam(id int) error {
 	u := c.BaseURL + pathTeamDelete
 	u = strings.ReplaceAll(u, "{teamID}", strconv.Itoa(id))
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetError(ErrorResult{}).
 		Delete(u)
 	if err != nil {
@@ -200,12 +200,11 @@ func (c *RollbarAPIClient) DeleteTeam(id int) error {
 }
 
 // AssignUserToTeam assigns a user to a Rollbar team.
-func (c *RollbarAPIClient) AssignUserToTeam(teamID, userID int) error {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) AssignUserToTeam(ctx context.Context, teamID, userID int) error {
 	l := log.With().Int("userID", userID).Int("teamID", teamID).Logger()
 	l.Debug().Msg("Assigning user to team")
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"teamID": strconv.Itoa(teamID),
 			"userID": strconv.Itoa(userID),
@@ -232,15 +231,14 @@ func (c *RollbarAPIClient) AssignUserToTeam(teamID, userID int) error {
 }
 
 // IsUserAssignedToTeam checks if a user is assigned to a Rollbar team.
-func (c *RollbarAPIClient) IsUserAssignedToTeam(teamID, userID int) (bool, error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) IsUserAssignedToTeam(ctx context.Context, teamID, userID int) (bool, error) {
 	l := log.With().
 		Int("userID", userID).
 		Int("teamID", teamID).
 		Logger()
 	l.Debug().Msg("Checking if user is assigned to team")
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"teamID": strconv.Itoa(teamID),
 			"userID": strconv.Itoa(userID),
@@ -265,12 +263,11 @@ func (c *RollbarAPIClient) IsUserAssignedToTeam(teamID, userID int) (bool, error
 }
 
 // RemoveUserFromTeam removes a user from a Rollbar team.
-func (c *RollbarAPIClient) RemoveUserFromTeam(userID, teamID int) error {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) RemoveUserFromTeam(ctx context.Context, userID, teamID int) error {
 	l := log.With().Int("userID", userID).Int("teamID", teamID).Logger()
 	l.Debug().Msg("Removing user from team")
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"teamID": strconv.Itoa(teamID),
 			"userID": strconv.Itoa(userID),
@@ -299,12 +296,12 @@ func (c *RollbarAPIClient) RemoveUserFromTeam(userID, teamID int) error {
 }
 
 // FindTeamID finds the ID for a team.
-func (c *RollbarAPIClient) FindTeamID(name string) (int, error) {
+func (c *RollbarAPIClient) FindTeamID(ctx context.Context, name string) (int, error) {
 	l := log.With().
 		Str("team_name", name).
 		Logger()
 	l.Debug().Msg("Finding team ID")
-	teams, err := c.ListTeams()
+	teams, err := c.ListTeams(ctx)
 	if err != nil {
 		l.Err(err).Send()
 		return 0, err
@@ -320,15 +317,14 @@ func (c *RollbarAPIClient) FindTeamID(name string) (int, error) {
 }
 
 // AssignTeamToProject assigns a Rollbar team to a project.
-func (c *RollbarAPIClient) AssignTeamToProject(teamID, projectID int) error {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) AssignTeamToProject(ctx context.Context, teamID, projectID int) error {
 	l := log.With().
 		Int("teamID", teamID).
 		Int("projectID", projectID).
 		Logger()
 	l.Debug().Msg("Assigning team to project")
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"teamID":    strconv.Itoa(teamID),
 			"projectID": strconv.Itoa(projectID),
@@ -349,15 +345,14 @@ func (c *RollbarAPIClient) AssignTeamToProject(teamID, projectID int) error {
 }
 
 // RemoveTeamFromProject removes a Rollbar team from a project.
-func (c *RollbarAPIClient) RemoveTeamFromProject(teamID, projectID int) error {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) RemoveTeamFromProject(ctx context.Context, teamID, projectID int) error {
 	l := log.With().
 		Int("teamID", teamID).
 		Int("projectID", projectID).
 		Logger()
 	l.Debug().Msg("Removing team from project")
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{
 			"teamID":    strconv.Itoa(teamID),
 			"projectID": strconv.Itoa(projectID),
@@ -381,6 +376,11 @@ func (c *RollbarAPIClient) RemoveTeamFromProject(teamID, projectID int) error {
  * Convenience functions
  */
 
+// teamKey identifies a team when deduplicating paginated results.
+func teamKey(t Team) int {
+	return t.ID
+}
+
 // filterSystemTeams filters out the system teams "Everyone" and "Owners" from a
 // list of Rollbar teams.
 func filterSystemTeams(teams []Team) []Team {
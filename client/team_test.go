@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -57,16 +58,16 @@ func (s *Suite) TestCreateTeam() {
 	httpmock.RegisterResponder("POST", u, r)
 
 	// Successful create
-	actual, err := s.client.CreateTeam(teamName, "standard")
+	actual, err := s.client.CreateTeam(context.Background(), teamName, "standard")
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	// Invalid name
-	_, err = s.client.CreateTeam("", "standard")
+	_, err = s.client.CreateTeam(context.Background(), "", "standard")
 	s.NotNil(err)
 
 	s.checkServerErrors("POST", u, func() error {
-		_, err = s.client.CreateTeam(teamName, "standard")
+		_, err = s.client.CreateTeam(context.Background(), teamName, "standard")
 		return err
 	})
 }
@@ -98,12 +99,12 @@ func (s *Suite) TestListTeams() {
 	httpmock.RegisterResponder("GET", u, r)
 
 	// Successful list
-	actual, err := s.client.ListTeams()
+	actual, err := s.client.ListTeams(context.Background())
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ListTeams()
+		_, err := s.client.ListTeams(context.Background())
 		return err
 	})
 }
@@ -123,21 +124,21 @@ func (s *Suite) TestReadTeam() {
 	httpmock.RegisterResponder("GET", u, r)
 
 	// Successful create
-	actual, err := s.client.ReadTeam(teamID)
+	actual, err := s.client.ReadTeam(context.Background(), teamID)
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	// Invalid ID
-	_, err = s.client.ReadTeam(0)
+	_, err = s.client.ReadTeam(context.Background(), 0)
 	s.NotNil(err)
 
 	r = responderFromFixture("team/read.json", http.StatusNotFound)
 	httpmock.RegisterResponder("GET", u, r)
-	_, err = s.client.ReadTeam(teamID)
-	s.Equal(ErrNotFound, err)
+	_, err = s.client.ReadTeam(context.Background(), teamID)
+	s.ErrorIs(err, ErrNotFound)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ReadTeam(teamID)
+		_, err := s.client.ReadTeam(context.Background(), teamID)
 		return err
 	})
 }
@@ -151,15 +152,15 @@ func (s *Suite) TestDeleteTeam() {
 	httpmock.RegisterResponder("DELETE", u, r)
 
 	// Successful delete
-	err := s.client.DeleteTeam(teamID)
+	err := s.client.DeleteTeam(context.Background(), teamID)
 	s.Nil(err)
 
 	// Invalid ID
-	err = s.client.DeleteTeam(0)
+	err = s.client.DeleteTeam(context.Background(), 0)
 	s.NotNil(err)
 
 	s.checkServerErrors("DELETE", u, func() error {
-		return s.client.DeleteTeam(teamID)
+		return s.client.DeleteTeam(context.Background(), teamID)
 	})
 }
 
@@ -174,11 +175,11 @@ func (s *Suite) TestAssignUserToTeam() {
 	u = strings.ReplaceAll(u, "{userID}", strconv.Itoa(userID))
 	r := responderFromFixture("team/assign_user.json", http.StatusOK)
 	httpmock.RegisterResponder("PUT", u, r)
-	err := s.client.AssignUserToTeam(teamID, userID)
+	err := s.client.AssignUserToTeam(context.Background(), teamID, userID)
 	s.Nil(err)
 
 	s.checkServerErrors("PUT", u, func() error {
-		err = s.client.AssignUserToTeam(teamID, userID) // non-existent user
+		err = s.client.AssignUserToTeam(context.Background(), teamID, userID) // non-existent user
 		return err
 	})
 
@@ -188,8 +189,8 @@ func (s *Suite) TestAssignUserToTeam() {
 	u = strings.ReplaceAll(u, "{userID}", "0")
 	r = responderFromFixture("team/assign_user_not_found.json", http.StatusForbidden)
 	httpmock.RegisterResponder("PUT", u, r)
-	err = s.client.AssignUserToTeam(teamID, 0) // non-existent user
-	s.Equal(ErrNotFound, err)
+	err = s.client.AssignUserToTeam(context.Background(), teamID, 0) // non-existent user
+	s.ErrorIs(err, ErrNotFound)
 }
 
 // TestIsUserAssignedToTeam tests if a user is assigned to a Rollbar team.
@@ -203,13 +204,13 @@ func (s *Suite) TestIsUserAssignedToTeam() {
 	u = strings.ReplaceAll(u, "{userID}", strconv.Itoa(userID))
 	r := responderFromFixture("team/check_user.json", http.StatusOK)
 	httpmock.RegisterResponder("GET", u, r)
-	result, err := s.client.IsUserAssignedToTeam(teamID, userID)
+	result, err := s.client.IsUserAssignedToTeam(context.Background(), teamID, userID)
 	s.Equal(true, result)
 	s.Nil(err)
 
 	// Cannot really check it because we have a custom logic for Not found
 	//s.checkServerErrors("GET", u, func() error {
-	//	_, err = s.client.IsUserAssignedToTeam(teamID, userID)
+	//	_, err = s.client.IsUserAssignedToTeam(context.Background(), teamID, userID)
 	//	return err
 	//})
 
@@ -219,7 +220,7 @@ func (s *Suite) TestIsUserAssignedToTeam() {
 	u = strings.ReplaceAll(u, "{userID}", "0")
 	r = responderFromFixture("team/check_user_not_found.json", http.StatusNotFound)
 	httpmock.RegisterResponder("GET", u, r)
-	result, err = s.client.IsUserAssignedToTeam(teamID, 0) // non-existent user
+	result, err = s.client.IsUserAssignedToTeam(context.Background(), teamID, 0) // non-existent user
 	s.Equal(false, result)
 	s.Nil(err)
 }
@@ -235,11 +236,11 @@ func (s *Suite) TestRemoveUserFromTeam() {
 	u = strings.ReplaceAll(u, "{userID}", strconv.Itoa(userID))
 	r := responderFromFixture("team/remove_user.json", http.StatusOK)
 	httpmock.RegisterResponder("DELETE", u, r)
-	err := s.client.RemoveUserFromTeam(userID, teamID)
+	err := s.client.RemoveUserFromTeam(context.Background(), userID, teamID)
 	s.Nil(err)
 
 	s.checkServerErrors("DELETE", u, func() error {
-		err = s.client.RemoveUserFromTeam(userID, teamID) // non-existent user
+		err = s.client.RemoveUserFromTeam(context.Background(), userID, teamID) // non-existent user
 		return err
 	})
 
@@ -249,8 +250,8 @@ func (s *Suite) TestRemoveUserFromTeam() {
 	u = strings.ReplaceAll(u, "{userID}", "0")
 	r = responderFromFixture("team/remove_user_not_found.json", http.StatusUnprocessableEntity)
 	httpmock.RegisterResponder("DELETE", u, r)
-	err = s.client.RemoveUserFromTeam(0, teamID) // non-existent user
-	s.Equal(ErrNotFound, err)
+	err = s.client.RemoveUserFromTeam(context.Background(), 0, teamID) // non-existent user
+	s.ErrorIs(err, ErrNotFound)
 }
 
 // TestListCustomTeams tests listing custom defined teams.
@@ -267,12 +268,12 @@ func (s *Suite) TestListCustomTeams() {
 	r := responderFromFixture("team/list.json", http.StatusOK)
 	httpmock.RegisterResponder("GET", u, r)
 
-	actual, err := s.client.ListCustomTeams()
+	actual, err := s.client.ListCustomTeams(context.Background())
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ListCustomTeams()
+		_, err := s.client.ListCustomTeams(context.Background())
 		return err
 	})
 }
@@ -283,16 +284,16 @@ func (s *Suite) TestFindTeamID() {
 	r := responderFromFixture("team/list.json", http.StatusOK)
 	httpmock.RegisterResponder("GET", u, r)
 
-	actual, err := s.client.FindTeamID("my-test-team")
+	actual, err := s.client.FindTeamID(context.Background(), "my-test-team")
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	// Non-existent team name
-	_, err = s.client.FindTeamID("does-not-exist")
-	s.Equal(ErrNotFound, err)
+	_, err = s.client.FindTeamID(context.Background(), "does-not-exist")
+	s.ErrorIs(err, ErrNotFound)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.FindTeamID("my-test-team")
+		_, err := s.client.FindTeamID(context.Background(), "my-test-team")
 		return err
 	})
 }
@@ -307,11 +308,11 @@ func (s *Suite) TestAssignTeamToProject() {
 	r := responderFromFixture("team/assign_project.json", http.StatusOK)
 	httpmock.RegisterResponder("PUT", u, r)
 
-	err := s.client.AssignTeamToProject(teamID, projectID)
+	err := s.client.AssignTeamToProject(context.Background(), teamID, projectID)
 	s.Nil(err)
 
 	s.checkServerErrors("PUT", u, func() error {
-		err := s.client.AssignTeamToProject(teamID, projectID)
+		err := s.client.AssignTeamToProject(context.Background(), teamID, projectID)
 		return err
 	})
 }
@@ -326,11 +327,11 @@ func (s *Suite) TestRemoveTeamFromProject() {
 	r := responderFromFixture("team/remove_project.json", http.StatusOK)
 	httpmock.RegisterResponder("DELETE", u, r)
 
-	err := s.client.RemoveTeamFromProject(teamID, projectID)
+	err := s.client.RemoveTeamFromProject(context.Background(), teamID, projectID)
 	s.Nil(err)
 
 	s.checkServerErrors("DELETE", u, func() error {
-		err := s.client.RemoveTeamFromProject(teamID, projectID)
+		err := s.client.RemoveTeamFromProject(context.Background(), teamID, projectID)
 		return err
 	})
 }
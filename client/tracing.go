@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies this package as the instrumentation library of the
+// spans it creates.
+const tracerName = "github.com/rollbar/terraform-provider-rollbar/client"
+
+// Span attributes identifying the Terraform resource or data source on whose
+// behalf an API request is made.
+const (
+	attrTerraformResource   = attribute.Key("terraform.resource_type")
+	attrTerraformDataSource = attribute.Key("terraform.data_source_type")
+)
+
+// tracingTransport is an http.RoundTripper that wraps every request sent
+// through it in an OpenTelemetry span.
+type tracingTransport struct {
+	next   http.RoundTripper
+	tracer trace.Tracer
+}
+
+// newTracingTransport wraps next, creating spans with a tracer from tp.  A nil
+// next means http.DefaultTransport.
+func newTracingTransport(next http.RoundTripper, tp trace.TracerProvider) *tracingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &tracingTransport{
+		next:   next,
+		tracer: tp.Tracer(tracerName, trace.WithInstrumentationVersion(Version)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	route := req.URL.Path
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if info, ok := ctx.Value(contextKeyRequestInfo).(*requestInfo); ok {
+		if info.route != "" {
+			route = info.route
+		}
+		if info.attempt > 1 {
+			attrs = append(attrs, semconv.HTTPResendCount(info.attempt-1))
+		}
+	}
+	attrs = append(attrs, semconv.HTTPRoute(route))
+	if name, ok := ctx.Value(contextKeyResource).(string); ok {
+		attrs = append(attrs, attrTerraformResource.String(name))
+	}
+	if name, ok := ctx.Value(contextKeyDataSource).(string); ok {
+		attrs = append(attrs, attrTerraformDataSource.String(name))
+	}
+
+	ctx, span := t.tracer.Start(ctx, req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	defer span.End()
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		msg := RedactString(err.Error())
+		span.RecordError(errors.New(msg))
+		span.SetStatus(codes.Error, msg)
+		return resp, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
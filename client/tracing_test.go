@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTracing checks that every attempt at a request gets its own span,
+// named after the path template and carrying the status code, retry count
+// and Terraform resource type.
+func (s *Suite) TestTracing() {
+	srv, _ := s.rateLimitServer(func(n int, w http.ResponseWriter) {
+		if n == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeProject(w)
+	})
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	opts := DefaultOptions()
+	opts.MinBackoff = time.Millisecond
+	opts.MaxBackoff = time.Millisecond
+	opts.TracerProvider = tp
+	c := NewClientWithOptions(srv.URL, "fakeTokenString", opts)
+
+	ctx := WithResource(context.Background(), "rollbar_project")
+	_, err := c.ReadProject(ctx, 1)
+	s.Nil(err)
+
+	spans := sr.Ended()
+	s.Len(spans, 2)
+	for i, span := range spans {
+		s.Equal("GET /api/1/project/{projectID}", span.Name())
+		attrs := spanAttributes(span)
+		s.Equal("/api/1/project/{projectID}", attrs["http.route"].AsString())
+		s.Equal(http.MethodGet, attrs["http.request.method"].AsString())
+		s.Equal("rollbar_project", attrs["terraform.resource_type"].AsString())
+		s.Equal(int64(i), attrs["http.resend_count"].AsInt64())
+	}
+	s.Equal(int64(http.StatusInternalServerError), spanAttributes(spans[0])["http.response.status_code"].AsInt64())
+	s.Equal(codes.Error, spans[0].Status().Code)
+	s.Equal(int64(http.StatusOK), spanAttributes(spans[1])["http.response.status_code"].AsInt64())
+	s.Equal(codes.Unset, spans[1].Status().Code)
+}
+
+// spanAttributes returns the attributes of span by key.
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+// TestTracingDisabled checks that no tracing transport is installed without
+// a tracer provider.
+func (s *Suite) TestTracingDisabled() {
+	c := NewClientWithOptions(DefaultBaseURL, "fakeTokenString", DefaultOptions())
+	_, ok := c.Resty.GetClient().Transport.(*tracingTransport)
+	s.False(ok)
+}
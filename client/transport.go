@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// TransportConfig configures how a client connects to the Rollbar API.  The
+// zero value connects directly, or through the proxy named by the standard
+// HTTPS_PROXY environment variables, and trusts the system CA pool.
+type TransportConfig struct {
+	// ProxyURL is the URL of an HTTP(S) proxy for all API requests.  It
+	// overrides the proxy environment variables.
+	ProxyURL string
+
+	// CACertPEM holds PEM encoded CA certificates to trust in addition to
+	// the system pool, e.g. that of a TLS-inspecting proxy.
+	CACertPEM []byte
+
+	// ClientCertPEM and ClientKeyPEM hold a PEM encoded client certificate
+	// and private key for mutual TLS.  Both or neither must be set.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+
+	// InsecureSkipVerify disables verification of the server's certificate.
+	// Use it only to test against an on-premises Rollbar.
+	InsecureSkipVerify bool
+}
+
+// IsZero reports whether tc is the zero value, i.e. needs no custom
+// transport.
+func (tc TransportConfig) IsZero() bool {
+	return tc.ProxyURL == "" && len(tc.CACertPEM) == 0 &&
+		len(tc.ClientCertPEM) == 0 && len(tc.ClientKeyPEM) == 0 &&
+		!tc.InsecureSkipVerify
+}
+
+// NewTransport returns an HTTP transport configured by tc, for use as
+// Options.Transport.  To record VCR cassettes through it, pass it as the real
+// transport to recorder.NewAsMode.
+func NewTransport(tc TransportConfig) (*http.Transport, error) {
+	t := cleanhttp.DefaultPooledTransport()
+	if tc.ProxyURL != "" {
+		u, err := url.Parse(tc.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: must be an absolute URL", RedactURL(u.Redacted()))
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: tc.InsecureSkipVerify, // #nosec G402 -- opt-in for on-premises testing
+	}
+	if len(tc.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(tc.CACertPEM) {
+			return nil, errors.New("no valid PEM encoded CA certificates found")
+		}
+		cfg.RootCAs = pool
+	}
+	switch {
+	case len(tc.ClientCertPEM) > 0 && len(tc.ClientKeyPEM) > 0:
+		cert, err := tls.X509KeyPair(tc.ClientCertPEM, tc.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	case len(tc.ClientCertPEM) > 0:
+		return nil, errors.New("client certificate given without a client key")
+	case len(tc.ClientKeyPEM) > 0:
+		return nil, errors.New("client key given without a client certificate")
+	}
+	t.TLSClientConfig = cfg
+	return t, nil
+}
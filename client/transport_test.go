@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dnaeon/go-vcr/recorder"
+)
+
+// projectHandler answers every request with a project.
+var projectHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	writeProject(w)
+})
+
+// readProjectVia reads a project from baseURL through a client using
+// transport tc.
+func (s *Suite) readProjectVia(baseURL string, tc TransportConfig) error {
+	t, err := NewTransport(tc)
+	s.Require().NoError(err)
+	opts := DefaultOptions()
+	opts.MaxRetries = 0
+	opts.Transport = t
+	c := NewClientWithOptions(baseURL, "fakeTokenString", opts)
+	_, err = c.ReadProject(context.Background(), 1)
+	return err
+}
+
+// serverCertPEM returns the PEM encoded certificate of a TLS test server.
+func serverCertPEM(srv *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+}
+
+// TestTransportCACert checks that a server whose certificate is signed by an
+// extra CA is trusted only when the CA is configured.
+func (s *Suite) TestTransportCACert() {
+	srv := httptest.NewTLSServer(projectHandler)
+	defer srv.Close()
+
+	s.NotNil(s.readProjectVia(srv.URL, TransportConfig{}))
+	s.Nil(s.readProjectVia(srv.URL, TransportConfig{CACertPEM: serverCertPEM(srv)}))
+	s.Nil(s.readProjectVia(srv.URL, TransportConfig{InsecureSkipVerify: true}))
+
+	_, err := NewTransport(TransportConfig{CACertPEM: []byte("not a certificate")})
+	s.NotNil(err)
+}
+
+// TestTransportClientCert checks mutual TLS with a client certificate.
+func (s *Suite) TestTransportClientCert() {
+	certPEM, keyPEM := s.selfSignedCert()
+	pool := x509.NewCertPool()
+	s.Require().True(pool.AppendCertsFromPEM(certPEM))
+	srv := httptest.NewUnstartedServer(projectHandler)
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	tc := TransportConfig{CACertPEM: serverCertPEM(srv)}
+	s.NotNil(s.readProjectVia(srv.URL, tc))
+	tc.ClientCertPEM, tc.ClientKeyPEM = certPEM, keyPEM
+	s.Nil(s.readProjectVia(srv.URL, tc))
+
+	_, err := NewTransport(TransportConfig{ClientCertPEM: certPEM})
+	s.NotNil(err)
+	_, err = NewTransport(TransportConfig{ClientKeyPEM: keyPEM})
+	s.NotNil(err)
+}
+
+// TestTransportProxy checks that requests go through the configured proxy,
+// and that VCR cassettes can be recorded through the custom transport.
+func (s *Suite) TestTransportProxy() {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		writeProject(w)
+	}))
+	defer proxy.Close()
+	const baseURL = "http://rollbar.invalid"
+
+	s.Nil(s.readProjectVia(baseURL, TransportConfig{ProxyURL: proxy.URL}))
+	s.Equal([]string{baseURL + "/api/1/project/1"}, proxied)
+
+	_, err := NewTransport(TransportConfig{ProxyURL: "not-a-url"})
+	s.NotNil(err)
+
+	// Record a cassette through the proxy
+	t, err := NewTransport(TransportConfig{ProxyURL: proxy.URL})
+	s.Require().NoError(err)
+	cassette := filepath.Join(s.T().TempDir(), "proxy")
+	r, err := recorder.NewAsMode(cassette, recorder.ModeRecording, t)
+	s.Require().NoError(err)
+	opts := DefaultOptions()
+	opts.Transport = r
+	c := NewClientWithOptions(baseURL, "fakeTokenString", opts)
+	_, err = c.ReadProject(context.Background(), 1)
+	s.Nil(err)
+	s.Require().NoError(r.Stop())
+	s.Len(proxied, 2)
+	_, err = os.Stat(cassette + ".yaml")
+	s.Nil(err)
+}
+
+// selfSignedCert returns a PEM encoded self-signed client certificate and its
+// private key.
+func (s *Suite) selfSignedCert() (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform-provider-rollbar test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	s.Require().NoError(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	s.Require().NoError(err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
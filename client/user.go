@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/rs/zerolog/log"
@@ -39,26 +40,13 @@ type User struct {
 }
 
 // ListUsers lists all Rollbar users.
-func (c *RollbarAPIClient) ListUsers(email string) (users []User, err error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ListUsers(ctx context.Context, email string) (users []User, err error) {
 	log.Debug().Msg("Listing users with email: " + email)
-	u := c.BaseURL + pathUsers
-	resp, err := c.Resty.R().
-		SetResult(userListResponse{}).
-		SetError(ErrorResult{}).
-		SetQueryParam("email", email).
-		Get(u)
-	if err != nil {
-		log.Err(err).Msg("Error listing users")
-		return
-	}
-	err = errorFromResponse(resp)
+	users, err = c.listUsers(ctx, map[string]string{"email": email})
 	if err != nil {
 		log.Err(err).Msg("Error listing users")
 		return
 	}
-	users = resp.Result().(*userListResponse).Result.Users
 	count := len(users)
 	log.Debug().
 		Int("count", count).
@@ -67,25 +55,13 @@ func (c *RollbarAPIClient) ListUsers(email string) (users []User, err error) {
 }
 
 // ListTestUsers is used only for testing purposes
-func (c *RollbarAPIClient) ListTestUsers() (users []User, err error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ListTestUsers(ctx context.Context) (users []User, err error) {
 	log.Debug().Msg("Listing users")
-	u := c.BaseURL + pathUsers
-	resp, err := c.Resty.R().
-		SetResult(userListResponse{}).
-		SetError(ErrorResult{}).
-		Get(u)
+	users, err = c.listUsers(ctx, nil)
 	if err != nil {
 		log.Err(err).Msg("Error listing users")
 		return
 	}
-	err = errorFromResponse(resp)
-	if err != nil {
-		log.Err(err).Msg("Error listing users")
-		return
-	}
-	users = resp.Result().(*userListResponse).Result.Users
 	count := len(users)
 	log.Debug().
 		Int("count", count).
@@ -93,14 +69,35 @@ func (c *RollbarAPIClient) ListTestUsers() (users []User, err error) {
 	return
 }
 
+// listUsers lists all Rollbar users matching the query parameters.
+func (c *RollbarAPIClient) listUsers(ctx context.Context, query map[string]string) ([]User, error) {
+	u := c.BaseURL + pathUsers
+	return listAll(ctx, func(ctx context.Context, page, perPage int) ([]User, error) {
+		resp, err := c.Resty.R().
+			SetContext(ctx).
+			SetResult(userListResponse{}).
+			SetError(ErrorResult{}).
+			SetQueryParams(query).
+			SetQueryParams(pageParams(page, perPage)).
+			Get(u)
+		if err != nil {
+			return nil, err
+		}
+		err = errorFromResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Result().(*userListResponse).Result.Users, nil
+	}, func(u User) int { return u.ID })
+}
+
 // ReadUser reads a Rollbar user from the API.
-func (c *RollbarAPIClient) ReadUser(id int) (user User, err error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ReadUser(ctx context.Context, id int) (user User, err error) {
 	l := log.With().Int("id", id).Logger()
 	l.Debug().Msg("Reading user from API")
 	u := c.BaseURL + pathUser
 	resp, err := c.Resty.R().
+		SetContext(ctx).
 		SetPathParams(map[string]string{"userID": strconv.Itoa(id)}).
 		SetResult(userReadResponse{}).
 		SetError(ErrorResult{}).
@@ -122,10 +119,10 @@ func (c *RollbarAPIClient) ReadUser(id int) (user User, err error) {
 }
 
 // FindUserID finds the user ID for a given email.
-func (c *RollbarAPIClient) FindUserID(email string) (int, error) {
+func (c *RollbarAPIClient) FindUserID(ctx context.Context, email string) (int, error) {
 	l := log.With().Str("email", email).Logger()
 	l.Debug().Msg("Getting user ID from email")
-	users, err := c.ListUsers(email)
+	users, err := c.ListUsers(ctx, email)
 	if err != nil {
 		l.Err(err).Msg("Error getting user ID from email")
 		return 0, err
@@ -141,27 +138,31 @@ func (c *RollbarAPIClient) FindUserID(email string) (int, error) {
 }
 
 // ListUserTeams lists a Rollbar user's teams.
-func (c *RollbarAPIClient) ListUserTeams(userID int) (teams []Team, err error) {
-	c.m.Lock()
-	defer c.m.Unlock()
+func (c *RollbarAPIClient) ListUserTeams(ctx context.Context, userID int) (teams []Team, err error) {
 	l := log.With().Int("userID", userID).Logger()
 	l.Debug().Msg("Reading teams for Rollbar user")
 	u := c.BaseURL + pathUserTeams
-	resp, err := c.Resty.R().
-		SetPathParams(map[string]string{"userID": strconv.Itoa(userID)}).
-		SetResult(userTeamListResponse{}).
-		SetError(ErrorResult{}).
-		Get(u)
-	if err != nil {
-		log.Err(err).Msg("Error reading Rollbar user's teams from API")
-		return
-	}
-	err = errorFromResponse(resp)
+	teams, err = listAll(ctx, func(ctx context.Context, page, perPage int) ([]Team, error) {
+		resp, err := c.Resty.R().
+			SetContext(ctx).
+			SetPathParams(map[string]string{"userID": strconv.Itoa(userID)}).
+			SetResult(userTeamListResponse{}).
+			SetError(ErrorResult{}).
+			SetQueryParams(pageParams(page, perPage)).
+			Get(u)
+		if err != nil {
+			return nil, err
+		}
+		err = errorFromResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Result().(*userTeamListResponse).Result.Teams, nil
+	}, teamKey)
 	if err != nil {
 		log.Err(err).Msg("Error reading Rollbar user's teams from API")
 		return
 	}
-	teams = resp.Result().(*userTeamListResponse).Result.Teams
 	log.Debug().
 		Interface("teams", teams).
 		Msg("Successfully read Rollbar user's teams from API")
@@ -170,8 +171,8 @@ func (c *RollbarAPIClient) ListUserTeams(userID int) (teams []Team, err error) {
 
 // ListUserCustomTeams lists a Rollbar user's custom defined teams, excluding
 // system teams "Everyone" and "Owners".
-func (c *RollbarAPIClient) ListUserCustomTeams(userID int) (teams []Team, err error) {
-	teams, err = c.ListUserTeams(userID)
+func (c *RollbarAPIClient) ListUserCustomTeams(ctx context.Context, userID int) (teams []Team, err error) {
+	teams, err = c.ListUserTeams(ctx, userID)
 	teams = filterSystemTeams(teams)
 	return
 }
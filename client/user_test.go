@@ -23,6 +23,7 @@
 package client
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -49,13 +50,13 @@ func (s *Suite) TestListUsers() {
 			Username: "coryvirok",
 		},
 	}
-	actual, err := s.client.ListTestUsers()
+	actual, err := s.client.ListTestUsers(context.Background())
 	s.Nil(err)
 	s.Subset(actual, expected)
 	s.Len(actual, len(expected))
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ListTestUsers()
+		_, err := s.client.ListTestUsers(context.Background())
 		return err
 	})
 }
@@ -76,12 +77,12 @@ func (s *Suite) TestReadUser() {
 		// https://github.com/rollbar/terraform-provider-rollbar/issues/65
 		//EmailEnabled: true,
 	}
-	actual, err := s.client.ReadUser(userID)
+	actual, err := s.client.ReadUser(context.Background(), userID)
 	s.Nil(err)
 	s.Equal(expected, actual)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ReadUser(userID)
+		_, err := s.client.ReadUser(context.Background(), userID)
 		return err
 	})
 }
@@ -95,15 +96,15 @@ func (s *Suite) TestUserIDFromEmail() {
 	r := responderFromFixture("user/list.json", http.StatusOK)
 	httpmock.RegisterResponder("GET", u, r)
 
-	actual, err := s.client.FindUserID(email)
+	actual, err := s.client.FindUserID(context.Background(), email)
 	s.Nil(err)
 	s.Equal(expected, actual)
 
-	_, err = s.client.FindUserID("fake email")
-	s.Equal(ErrNotFound, err)
+	_, err = s.client.FindUserID(context.Background(), "fake email")
+	s.ErrorIs(err, ErrNotFound)
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.FindUserID(email)
+		_, err := s.client.FindUserID(context.Background(), email)
 		return err
 	})
 }
@@ -137,13 +138,13 @@ func (s *Suite) TestListUserTeams() {
 			Name:        "my-test-team",
 		},
 	}
-	actual, err := s.client.ListUserTeams(userID)
+	actual, err := s.client.ListUserTeams(context.Background(), userID)
 	s.Nil(err)
 	s.Subset(actual, expected)
 	s.Len(actual, len(expected))
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ListUserTeams(userID)
+		_, err := s.client.ListUserTeams(context.Background(), userID)
 		return err
 	})
 }
@@ -165,13 +166,13 @@ func (s *Suite) TestListUserCustomTeams() {
 			Name:        "my-test-team",
 		},
 	}
-	actual, err := s.client.ListUserCustomTeams(userID)
+	actual, err := s.client.ListUserCustomTeams(context.Background(), userID)
 	s.Nil(err)
 	s.Subset(actual, expected)
 	s.Len(actual, len(expected))
 
 	s.checkServerErrors("GET", u, func() error {
-		_, err := s.client.ListUserCustomTeams(userID)
+		_, err := s.client.ListUserCustomTeams(context.Background(), userID)
 		return err
 	})
 }
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Environment variables controlling where the provider logs.
+const (
+	// envLogFile is the path of a file to which log events are appended, in
+	// addition to Terraform's log.
+	envLogFile = "TERRAFORM_PROVIDER_ROLLBAR_LOG_FILE"
+
+	// envLogFormat is the format of the log file: "json" (the default) or
+	// "console".
+	envLogFormat = "TERRAFORM_PROVIDER_ROLLBAR_LOG_FORMAT"
+
+	// envDebug, if set to "1", logs to legacyLogFile in console format unless
+	// envLogFile is set.
+	envDebug = "TERRAFORM_PROVIDER_ROLLBAR_DEBUG"
+)
+
+// legacyLogFile is where the provider used to log when envDebug was set.
+const legacyLogFile = "/tmp/terraform-provider-rollbar.log"
+
+// providerLoggerName is the name of the provider's Terraform logger.  Its level
+// can be set independently of other providers' with environment variable
+// TF_LOG_PROVIDER_ROLLBAR.
+const providerLoggerName = "rollbar"
+
+// configureLogging routes the global zerolog logger through Terraform's
+// provider logger, so log events show up in Terraform's own log, filtered by
+// TF_LOG and TF_LOG_PROVIDER.  If envLogFile is set, events are also appended
+// to that file.  Secrets are masked in all log output.  The returned function
+// closes the log file, if any.
+func configureLogging() (func(), error) {
+	ctx := tfsdklog.NewRootProviderLogger(context.Background(),
+		tfsdklog.WithLogName(providerLoggerName),
+		tflog.WithLevelFromEnv("TF_LOG_PROVIDER", providerLoggerName),
+		tfsdklog.WithStderrFromInit(),
+		tfsdklog.WithoutLocation(),
+	)
+	writers := []io.Writer{tflogWriter{ctx: ctx}}
+	closeLog := func() {}
+
+	path := os.Getenv(envLogFile)
+	format := os.Getenv(envLogFormat)
+	if path == "" && os.Getenv(envDebug) == "1" {
+		path = legacyLogFile
+		if format == "" {
+			format = "console"
+		}
+	}
+	if path != "" {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600) // #nosec
+		if err != nil {
+			return closeLog, fmt.Errorf("error opening log file: %w", err)
+		}
+		closeLog = func() { _ = f.Close() }
+		switch format {
+		case "", "json":
+			writers = append(writers, f)
+		case "console":
+			writers = append(writers, zerolog.ConsoleWriter{Out: f, NoColor: true})
+		default:
+			closeLog()
+			return func() {}, fmt.Errorf("invalid %s %q: must be \"json\" or \"console\"", envLogFormat, format)
+		}
+	}
+
+	w := client.NewRedactingWriter(zerolog.MultiLevelWriter(writers...))
+	log.Logger = zerolog.New(w).
+		With().Timestamp().Caller().
+		Logger()
+	zerolog.SetGlobalLevel(zerolog.DebugLevel)
+	return closeLog, nil
+}
+
+// tflogWriter is a zerolog.LevelWriter that forwards log events to the
+// Terraform provider logger in ctx, with their fields as structured fields.
+type tflogWriter struct {
+	ctx context.Context
+}
+
+// Write implements io.Writer.
+func (w tflogWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+func (w tflogWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	var fields map[string]interface{}
+	err := json.Unmarshal(p, &fields)
+	if err != nil {
+		return 0, err
+	}
+	msg, _ := fields[zerolog.MessageFieldName].(string)
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, zerolog.LevelFieldName)
+	delete(fields, zerolog.TimestampFieldName) // Terraform adds its own
+
+	switch level {
+	case zerolog.TraceLevel:
+		tflog.Trace(w.ctx, msg, fields)
+	case zerolog.DebugLevel:
+		tflog.Debug(w.ctx, msg, fields)
+	case zerolog.InfoLevel, zerolog.NoLevel:
+		tflog.Info(w.ctx, msg, fields)
+	case zerolog.WarnLevel:
+		tflog.Warn(w.ctx, msg, fields)
+	default:
+		tflog.Error(w.ctx, msg, fields)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTflogWriter checks that zerolog events are forwarded to the Terraform
+// provider logger with their level and fields.
+func TestTflogWriter(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &buf)
+	l := zerolog.New(tflogWriter{ctx: ctx}).With().Timestamp().Logger()
+
+	l.Warn().Int("project_id", 42).Msg("Project not found")
+
+	entries, err := tflogtest.MultilineJSONDecode(&buf)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "warn", entries[0]["@level"])
+	assert.Equal(t, "Project not found", entries[0]["@message"])
+	assert.Equal(t, float64(42), entries[0]["project_id"])
+	assert.NotContains(t, entries[0], zerolog.TimestampFieldName)
+}
+
+// TestConfigureLoggingFile checks that log events are also written as JSON to
+// the configured log file.
+func TestConfigureLoggingFile(t *testing.T) {
+	orig := log.Logger
+	defer func() { log.Logger = orig }()
+	path := filepath.Join(t.TempDir(), "provider.log")
+	t.Setenv(envLogFile, path)
+	t.Setenv(envLogFormat, "")
+
+	closeLog, err := configureLogging()
+	require.NoError(t, err)
+	log.Debug().Str("name", "foo").Msg("Creating new project")
+	closeLog()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &event))
+	assert.Equal(t, "debug", event["level"])
+	assert.Equal(t, "Creating new project", event["message"])
+	assert.Equal(t, "foo", event["name"])
+}
+
+// TestConfigureLoggingInvalidFormat checks that an unknown log file format is
+// rejected.
+func TestConfigureLoggingInvalidFormat(t *testing.T) {
+	orig := log.Logger
+	defer func() { log.Logger = orig }()
+	t.Setenv(envLogFile, filepath.Join(t.TempDir(), "provider.log"))
+	t.Setenv(envLogFormat, "xml")
+
+	_, err := configureLogging()
+	assert.Error(t, err)
+}
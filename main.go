@@ -23,35 +23,49 @@
 package main
 
 import (
-	"os"
+	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/rollbar/terraform-provider-rollbar/rollbar"
-	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
-func main() {
-	// Configure logging
-	if os.Getenv("TERRAFORM_PROVIDER_ROLLBAR_DEBUG") == "1" {
-		p := "/tmp/terraform-provider-rollbar.log"
-		f, err := os.OpenFile(p, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
-		if err != nil {
-			log.Fatal().
-				Err(err).
-				Msg("Error opening log file")
-		}
-		defer f.Close() // #nosec
-		log.Logger = log.
-			Output(zerolog.ConsoleWriter{Out: f}).
-			With().Caller().
-			Logger()
-		zerolog.SetGlobalLevel(zerolog.DebugLevel)
+// shutdownTracing flushes spans buffered by the provider's tracer, giving up
+// after tracingShutdownTimeout.
+func shutdownTracing() {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := rollbar.ShutdownTracing(ctx); err != nil {
+		log.Warn().
+			Err(err).
+			Msg("Error flushing OpenTelemetry spans")
+	}
+}
 
+// tracingShutdownTimeout bounds how long the provider waits to export spans
+// when it exits.
+const tracingShutdownTimeout = 5 * time.Second
+
+func main() {
+	closeLog, err := configureLogging()
+	if err != nil {
+		log.Fatal().
+			Err(err).
+			Msg("Error configuring logging")
 	}
+	defer closeLog()
+	defer shutdownTracing()
+	defer writeStats()
 
 	// Serve the plugin
+	providerServer, err := rollbar.ProviderServer(context.Background())
+	if err != nil {
+		log.Fatal().
+			Err(err).
+			Msg("Error creating provider server")
+	}
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: rollbar.Provider,
+		GRPCProviderFunc: providerServer,
 	})
 }
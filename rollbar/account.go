@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rs/zerolog/log"
+)
+
+// accountID determines the Rollbar account to which the provider's account
+// access token belongs.  Every account has the system teams, so listing teams
+// normally suffices; projects are tried too in case teams are not visible.
+func accountID(ctx context.Context, m *Meta) (int, error) {
+	teams, err := m.Teams.ListTeams(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, t := range teams {
+		if t.AccountID != 0 {
+			return t.AccountID, nil
+		}
+	}
+	projects, err := m.Projects.ListProjects(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range projects {
+		if p.AccountID != 0 {
+			return p.AccountID, nil
+		}
+	}
+	return 0, errors.New("no teams or projects visible to determine the account from")
+}
+
+// checkAccount refuses to configure the provider unless the account of its
+// account access token is in allowed, if not empty, and not in forbidden.  If
+// id is zero the account is looked up.
+func checkAccount(ctx context.Context, m *Meta, id int, allowed, forbidden []int) diag.Diagnostics {
+	if len(allowed) == 0 && len(forbidden) == 0 {
+		return nil
+	}
+	var err error
+	if id == 0 {
+		id, err = accountID(ctx, m)
+	}
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to determine Rollbar account",
+			Detail: fmt.Sprintf("%s or %s is set, but the account of the API key could not be determined: %s",
+				schemaKeyAllowedAccountIDs, schemaKeyForbiddenAccountIDs, err),
+		}}
+	}
+	log.Debug().Int("account_id", id).Msg("Determined Rollbar account")
+	for _, f := range forbidden {
+		if id == f {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Forbidden Rollbar account",
+				Detail:   fmt.Sprintf("The API key belongs to Rollbar account %d, which is listed in %s.", id, schemaKeyForbiddenAccountIDs),
+			}}
+		}
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, a := range allowed {
+		if id == a {
+			return nil
+		}
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  "Rollbar account not allowed",
+		Detail:   fmt.Sprintf("The API key belongs to Rollbar account %d, which is not listed in %s %v.", id, schemaKeyAllowedAccountIDs, allowed),
+	}}
+}
+
+// checkProjectTokenAccounts refuses project access tokens that cannot be shown
+// to belong to the account checked by checkAccount, that of api_key: each must
+// be found with api among the access tokens of its project.  The project of
+// projectToken, from project_api_key, is read with projectAPI; those of
+// projectTokens, from project_api_keys, are their keys.
+func checkProjectTokenAccounts(ctx context.Context, api client.ProjectAccessTokensAPI, projectAPI client.IntegrationsAPI, projectToken string, projectTokens map[int]string, allowed, forbidden []int) diag.Diagnostics {
+	if len(allowed) == 0 && len(forbidden) == 0 {
+		return nil
+	}
+	var diags diag.Diagnostics
+	check := func(key string, projectID int, token string, err error) {
+		if err == nil {
+			_, err = api.ReadProjectAccessToken(ctx, projectID, token)
+		}
+		if err == nil {
+			return
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to determine Rollbar account",
+			Detail: fmt.Sprintf("%s or %s is set, but %s could not be found with %s among the access tokens of its project: %s",
+				schemaKeyAllowedAccountIDs, schemaKeyForbiddenAccountIDs, key, schemaKeyToken, err),
+		})
+	}
+	if projectToken != "" {
+		projectID, err := tokenProjectID(ctx, projectAPI)
+		check(projectKeyToken, projectID, projectToken, err)
+	}
+	ids := make([]int, 0, len(projectTokens))
+	for id := range projectTokens {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		check(fmt.Sprintf("%s[%q]", schemaKeyProjectTokens, strconv.Itoa(id)), id, projectTokens[id], nil)
+	}
+	return diags
+}
+
+// intSet returns the integers in set attribute key of d.
+func intSet(d *schema.ResourceData, key string) []int {
+	var ints []int
+	for _, v := range d.Get(key).(*schema.Set).List() {
+		ints = append(ints, v.(int))
+	}
+	return ints
+}
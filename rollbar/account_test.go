@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rollbar/terraform-provider-rollbar/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCheckAccount checks that the provider refuses accounts that are not
+// allowed or are forbidden.
+func TestCheckAccount(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	m := NewMeta(fake, fake)
+	const other = clienttest.AccountID + 1
+
+	assert.False(t, checkAccount(ctx, m, 0, nil, nil).HasError())
+	assert.False(t, checkAccount(ctx, m, 0, []int{other, clienttest.AccountID}, nil).HasError())
+	assert.False(t, checkAccount(ctx, m, 0, nil, []int{other}).HasError())
+
+	diags := checkAccount(ctx, m, 0, []int{other}, nil)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Rollbar account not allowed", diags[0].Summary)
+
+	diags = checkAccount(ctx, m, 0, nil, []int{clienttest.AccountID})
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Forbidden Rollbar account", diags[0].Summary)
+
+	// An account already known is not looked up again
+	diags = checkAccount(ctx, m, other, []int{other}, nil)
+	assert.False(t, diags.HasError())
+}
+
+// TestCheckProjectTokenAccounts checks that with account guardrails, project
+// access tokens must be found with api_key in their projects.
+func TestCheckProjectTokenAccounts(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	pats, err := fake.ListProjectAccessTokens(ctx, p.ID)
+	require.NoError(t, err)
+	token := pats[0].AccessToken
+	projectAPI := slackFake{fake, p.ID}
+	allowed := []int{clienttest.AccountID}
+
+	diags := checkProjectTokenAccounts(ctx, fake, projectAPI, token, map[int]string{p.ID: token}, allowed, nil)
+	assert.False(t, diags.HasError(), diags)
+
+	// Tokens of another account are not found with api_key
+	diags = checkProjectTokenAccounts(ctx, fake, projectAPI, "other", map[int]string{p.ID: "other"}, allowed, nil)
+	assert.Equal(t, []string{"error: Unable to determine Rollbar account", "error: Unable to determine Rollbar account"}, summaries(diags))
+	assert.Contains(t, diags[0].Detail, "project_api_key ")
+	assert.Contains(t, diags[1].Detail, "project_api_keys")
+
+	// The project of project_api_key is unknown
+	diags = checkProjectTokenAccounts(ctx, fake, fake, token, nil, nil, []int{clienttest.AccountID + 1})
+	assert.True(t, diags.HasError())
+
+	// No guardrails
+	diags = checkProjectTokenAccounts(ctx, fake, fake, "other", map[int]string{p.ID: "other"}, nil, nil)
+	assert.Empty(t, diags)
+}
@@ -24,6 +24,11 @@ package rollbar
 
 const ComplexImportSeparator = ","
 
+// schemaKeyProjectID is the argument of project level resources naming the
+// project whose access token, from provider argument project_api_keys, they
+// use.
+const schemaKeyProjectID = "project_id"
+
 const (
 	rollbarProject             = "rollbar_project"
 	rollbarProjects            = "rollbar_projects"
@@ -23,15 +23,17 @@
 package rollbar
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/rollbar/terraform-provider-rollbar/client"
 )
 
 func dataSourceProject() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceProjectRead,
+		ReadContext: dataSourceProjectRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -70,15 +72,15 @@ func dataSourceProject() *schema.Resource {
 	}
 }
 
-func dataSourceProjectRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 
-	c := meta.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderDataSource(rollbarProject)
- 
-	pl, err := c.ListProjects()
+	c := meta.(*Meta)
+	ctx = client.WithDataSource(ctx, rollbarProject)
+
+	pl, err := c.Projects.ListProjects(ctx)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	var project client.Project
@@ -91,7 +93,7 @@ func dataSourceProjectRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	if !found {
 		d.SetId("")
-		return fmt.Errorf("no project with the name %s found", name)
+		return diag.Errorf("no project with the name %s found", name)
 	}
 
 	id := fmt.Sprintf("%d", project.ID)
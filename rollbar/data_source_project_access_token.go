@@ -117,11 +117,11 @@ func dataSourceProjectAccessTokenRead(ctx context.Context, d *schema.ResourceDat
 		Logger()
 	l.Debug().Msg("Reading project access token from Rollbar")
 
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderDataSource(rollbarProjectAccessToken)
-	tokens, err := c.ListProjectAccessTokens(projectID)
+	c := m.(*Meta)
+	ctx = client.WithDataSource(ctx, rollbarProjectAccessToken)
+	tokens, err := c.ProjectAccessTokens.ListProjectAccessTokens(ctx, projectID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Look for a token with matching name
@@ -136,7 +136,7 @@ func dataSourceProjectAccessTokenRead(ctx context.Context, d *schema.ResourceDat
 	if found == nil {
 		msg := fmt.Sprintf(`could not find access token with name matching %q`, name)
 		l.Error().Msg(msg)
-		return diag.FromErr(errors.New(msg))
+		return diagFromErr(errors.New(msg))
 	}
 
 	// Write the values from API to Terraform state
@@ -24,10 +24,13 @@ package rollbar
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/rollbar/terraform-provider-rollbar/client"
@@ -52,6 +55,41 @@ func dataSourceProjectAccessTokens() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 			},
+			"name_regex": {
+				Description:      "Name of the token matches this regular expression",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateRegexp,
+			},
+			"scopes": {
+				Description: "Token has these scopes, all or any of them as set by scopes_match",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateDiagFunc: validateTokenScope},
+			},
+			"scopes_match": {
+				Description:      `Whether a token must have "all" of the scopes, or "any" of them`,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "all",
+				ValidateDiagFunc: validateScopesMatch,
+			},
+			"status": {
+				Description:      `Status of the token, "enabled" or "disabled"`,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateTokenStatus,
+			},
+			"rate_limited": {
+				Description: "Whether the token has a rate limit",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"exactly_one": {
+				Description: "Fail unless exactly one token matches",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
 
 			// Computed fields
 			"access_tokens": {
@@ -123,33 +161,126 @@ func dataSourceProjectAccessTokens() *schema.Resource {
 	}
 }
 
+// validateRegexp checks that a string attribute is a valid regular expression.
+func validateRegexp(v interface{}, p cty.Path) diag.Diagnostics {
+	if _, err := regexp.Compile(v.(string)); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid regular expression",
+			Detail:        err.Error(),
+			AttributePath: p,
+		}}
+	}
+	return nil
+}
+
+func validateScopesMatch(v interface{}, p cty.Path) diag.Diagnostics {
+	s := v.(string)
+	switch s {
+	case "all", "any":
+		return nil
+	default:
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			AttributePath: p,
+			Summary:       fmt.Sprintf(`Invalid scopes_match: %q`, s),
+			Detail:        `Must be "all" or "any"`,
+		}}
+	}
+}
+
+// projectAccessTokenFilter selects project access tokens.  Zero fields match
+// any token.
+type projectAccessTokenFilter struct {
+	prefix      string
+	nameRegexp  *regexp.Regexp
+	scopes      []client.Scope
+	anyScope    bool
+	status      client.Status
+	rateLimited *bool
+}
+
+// projectAccessTokenFilterFromData returns the filter configured in d.
+func projectAccessTokenFilterFromData(d *schema.ResourceData) projectAccessTokenFilter {
+	f := projectAccessTokenFilter{
+		prefix:   d.Get("prefix").(string),
+		anyScope: d.Get("scopes_match").(string) == "any",
+		status:   client.Status(d.Get("status").(string)),
+	}
+	if re := d.Get("name_regex").(string); re != "" {
+		// Validated by the schema
+		f.nameRegexp = regexp.MustCompile(re)
+	}
+	for _, s := range d.Get("scopes").(*schema.Set).List() {
+		f.scopes = append(f.scopes, client.Scope(s.(string)))
+	}
+	// Unset is not the same as false
+	if v, ok := d.GetOkExists("rate_limited"); ok { //nolint:staticcheck
+		rateLimited := v.(bool)
+		f.rateLimited = &rateLimited
+	}
+	return f
+}
+
+// match reports whether the token is selected by the filter.
+func (f projectAccessTokenFilter) match(t client.ProjectAccessToken) bool {
+	switch {
+	case !strings.HasPrefix(t.Name, f.prefix):
+		return false
+	case f.nameRegexp != nil && !f.nameRegexp.MatchString(t.Name):
+		return false
+	case f.status != "" && t.Status != f.status:
+		return false
+	case f.rateLimited != nil && (t.RateLimitWindowCount > 0) != *f.rateLimited:
+		return false
+	case len(f.scopes) == 0:
+		return true
+	case f.anyScope:
+		for _, s := range f.scopes {
+			if hasScopes(t.Scopes, []client.Scope{s}) {
+				return true
+			}
+		}
+		return false
+	}
+	return hasScopes(t.Scopes, f.scopes)
+}
+
 // dataSourceProjectAccessTokensRead reads project access token data from Rollbar
 func dataSourceProjectAccessTokensRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	projectID := d.Get("project_id").(int)
-	var prefix string
-	prefix, _ = d.Get("prefix").(string)
+	f := projectAccessTokenFilterFromData(d)
 	l := log.With().
 		Int("project_id", projectID).
-		Str("prefix", prefix).
+		Str("prefix", f.prefix).
 		Logger()
 	l.Debug().Msg("Reading project access token data from Rollbar")
 
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderDataSource(rollbarProjectAccessTokens)
-	tokens, err := c.ListProjectAccessTokens(projectID)
+	c := m.(*Meta)
+	ctx = client.WithDataSource(ctx, rollbarProjectAccessTokens)
+	tokens, err := c.ProjectAccessTokens.ListProjectAccessTokens(ctx, projectID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	var filtered []client.ProjectAccessToken
-	if prefix == "" {
-		filtered = tokens
-	} else {
-		for _, t := range tokens {
-			if strings.HasPrefix(t.Name, prefix) {
-				filtered = append(filtered, t)
-			}
+	var names []string
+	for _, t := range tokens {
+		if f.match(t) {
+			filtered = append(filtered, t)
+			names = append(names, strconv.Quote(t.Name))
+		}
+	}
+	if d.Get("exactly_one").(bool) && len(filtered) != 1 {
+		detail := fmt.Sprintf("No access token of project %d matches the filters.", projectID)
+		if len(filtered) > 1 {
+			detail = fmt.Sprintf("%d access tokens of project %d match the filters: %s.", len(filtered), projectID, strings.Join(names, ", "))
 		}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Expected exactly one matching project access token",
+			Detail:   detail,
+		}}
 	}
 	mustSet(d, "access_tokens", filtered)
 
@@ -159,3 +290,37 @@ func dataSourceProjectAccessTokensRead(ctx context.Context, d *schema.ResourceDa
 
 	return nil
 }
+
+// validateTokenScope checks that a string attribute is a project access token
+// scope.
+func validateTokenScope(v interface{}, p cty.Path) diag.Diagnostics {
+	s := client.Scope(v.(string))
+	switch s {
+	case client.ScopeRead, client.ScopeWrite, client.ScopePostServerItem, client.ScopePostClientItem:
+		return nil
+	default:
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			AttributePath: p,
+			Summary:       fmt.Sprintf(`Invalid scope: %q`, s),
+			Detail:        `Must be "read", "write", "post_server_item", or "post_client_item"`,
+		}}
+	}
+}
+
+// validateTokenStatus checks that a string attribute is a project access token
+// status.
+func validateTokenStatus(v interface{}, p cty.Path) diag.Diagnostics {
+	s := client.Status(v.(string))
+	switch s {
+	case client.StatusEnabled, client.StatusDisabled:
+		return nil
+	default:
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			AttributePath: p,
+			Summary:       fmt.Sprintf(`Invalid status: %q`, s),
+			Detail:        `Must be "enabled" or "disabled"`,
+		}}
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rollbar/terraform-provider-rollbar/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDataSourceProjectAccessTokensFilters checks that tokens are filtered by
+// name, scopes, status and rate limit, and that exactly_one fails unless a
+// single token matches.
+func TestDataSourceProjectAccessTokensFilters(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	meta := NewMeta(fake, fake)
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	for _, args := range []client.ProjectAccessTokenCreateArgs{
+		{Name: "ci-server", Scopes: []client.Scope{client.ScopePostServerItem}, Status: client.StatusEnabled},
+		{Name: "ci-server-old", Scopes: []client.Scope{client.ScopePostServerItem}, Status: client.StatusDisabled},
+		{Name: "ci-client", Scopes: []client.Scope{client.ScopePostClientItem}, Status: client.StatusEnabled, RateLimitWindowCount: 100, RateLimitWindowSize: 60},
+		{Name: "admin", Scopes: []client.Scope{client.ScopeRead, client.ScopeWrite}, Status: client.StatusEnabled},
+	} {
+		args.ProjectID = p.ID
+		_, err = fake.CreateProjectAccessToken(ctx, args)
+		require.NoError(t, err)
+	}
+	r := dataSourceProjectAccessTokens()
+
+	// names returns the names of the tokens matching raw.
+	names := func(raw map[string]interface{}) []string {
+		raw["project_id"] = p.ID
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		diags := dataSourceProjectAccessTokensRead(ctx, d, meta)
+		require.False(t, diags.HasError(), diags)
+		var ns []string
+		for _, t := range d.Get("access_tokens").([]interface{}) {
+			ns = append(ns, t.(map[string]interface{})["name"].(string))
+		}
+		return ns
+	}
+
+	assert.ElementsMatch(t, []string{"ci-server", "ci-server-old", "ci-client"}, names(map[string]interface{}{"prefix": "ci-"}))
+	assert.ElementsMatch(t, []string{"ci-server", "ci-client"}, names(map[string]interface{}{"name_regex": "^ci-[a-z]+$"}))
+	// The project also has a token named after each scope
+	assert.ElementsMatch(t, []string{"ci-server", "post_server_item"}, names(map[string]interface{}{
+		"scopes": []interface{}{"post_server_item"},
+		"status": "enabled",
+	}))
+	assert.Empty(t, names(map[string]interface{}{"scopes": []interface{}{"read", "post_server_item"}}))
+	assert.ElementsMatch(t, []string{"ci-server", "ci-server-old", "admin", "read", "post_server_item"}, names(map[string]interface{}{
+		"scopes":       []interface{}{"read", "post_server_item"},
+		"scopes_match": "any",
+	}))
+	assert.ElementsMatch(t, []string{"ci-client"}, names(map[string]interface{}{"rate_limited": true}))
+	assert.NotContains(t, names(map[string]interface{}{"rate_limited": false}), "ci-client")
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_id":  p.ID,
+		"prefix":      "ci-server",
+		"exactly_one": true,
+	})
+	diags := dataSourceProjectAccessTokensRead(ctx, d, meta)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Expected exactly one matching project access token", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, `"ci-server", "ci-server-old"`)
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_id":  p.ID,
+		"prefix":      "ci-server",
+		"status":      "enabled",
+		"exactly_one": true,
+	})
+	diags = dataSourceProjectAccessTokensRead(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Len(t, d.Get("access_tokens").([]interface{}), 1)
+
+	// Typos are caught by validation
+	diags = r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": p.ID,
+		"status":     "enable",
+		"scopes":     []interface{}{"post_server_items"},
+	}))
+	assert.ElementsMatch(t, []string{
+		`error: Invalid status: "enable"`,
+		`error: Invalid scope: "post_server_items"`,
+	}, summaries(diags))
+}
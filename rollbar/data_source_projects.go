@@ -83,12 +83,12 @@ func dataSourceProjects() *schema.Resource {
 func dataSourceProjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Debug().Msg("Reading project list from API")
 	var diags diag.Diagnostics
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderDataSource(rollbarProjects)
-	projects, err := c.ListProjects()
+	c := m.(*Meta)
+	ctx = client.WithDataSource(ctx, rollbarProjects)
+	projects, err := c.Projects.ListProjects(ctx)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	mustSet(d, "projects", projects)
 
@@ -70,15 +70,15 @@ func dataSourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface
 	var team client.Team
 	var l zerolog.Logger
 	teamID, ok := d.GetOk("team_id")
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderDataSource(rollbarTeam)
+	c := m.(*Meta)
+	ctx = client.WithDataSource(ctx, rollbarTeam)
 
 	if ok {
 		l = log.With().
 			Int("id", teamID.(int)).
 			Logger()
 		l.Debug().Msg("Reading Team from Rollbar by ID")
-		respTeam, err := c.ReadTeam(teamID.(int))
+		respTeam, err := c.Teams.ReadTeam(ctx, teamID.(int))
 		if err != nil {
 			return diag.Errorf("Team not found by ID: %v", err)
 		}
@@ -93,14 +93,14 @@ func dataSourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface
 			Logger()
 		l.Debug().Msg("Reading team from Rollbar by name")
 
-		teams, err := c.ListTeams()
+		teams, err := c.Teams.ListTeams(ctx)
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 
 		t, err := findTeamByName(teams, name.(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		team = t
 	}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	eschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rs/zerolog/log"
+)
+
+// ephemeralProjectAccessToken is an ephemeral resource returning an access
+// token of a Rollbar project, whose value is never stored in state or plan.
+type ephemeralProjectAccessToken struct {
+	// meta returns the *Meta of the configured provider.
+	meta func() interface{}
+}
+
+// ephemeralProjectAccessTokenModel is the data of the
+// rollbar_project_access_token ephemeral resource.
+type ephemeralProjectAccessTokenModel struct {
+	ProjectID            types.Int64  `tfsdk:"project_id"`
+	Name                 types.String `tfsdk:"name"`
+	AccessToken          types.String `tfsdk:"access_token"`
+	Scopes               []string     `tfsdk:"scopes"`
+	Status               types.String `tfsdk:"status"`
+	RateLimitWindowCount types.Int64  `tfsdk:"rate_limit_window_count"`
+	RateLimitWindowSize  types.Int64  `tfsdk:"rate_limit_window_size"`
+}
+
+var _ ephemeral.EphemeralResource = &ephemeralProjectAccessToken{}
+
+// Metadata implements ephemeral.EphemeralResource.
+func (e *ephemeralProjectAccessToken) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = rollbarProjectAccessToken
+}
+
+// Schema implements ephemeral.EphemeralResource.
+func (e *ephemeralProjectAccessToken) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = eschema.Schema{
+		Description: "An access token of a Rollbar project, which is not stored in state",
+		Attributes: map[string]eschema.Attribute{
+			// Required fields
+			"project_id": eschema.Int64Attribute{
+				Description: "ID of a Rollbar project",
+				Required:    true,
+			},
+			"name": eschema.StringAttribute{
+				Description: "Name of the token",
+				Required:    true,
+			},
+
+			// Computed fields
+			"access_token": eschema.StringAttribute{
+				Description: "Access token for Rollbar API",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"scopes": eschema.ListAttribute{
+				Description: "Project access scopes for the token",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"status": eschema.StringAttribute{
+				Description: "Status of the token",
+				Computed:    true,
+			},
+			"rate_limit_window_count": eschema.Int64Attribute{
+				Description: "Maximum allowed API hits during a rate limit window",
+				Computed:    true,
+			},
+			"rate_limit_window_size": eschema.Int64Attribute{
+				Description: "Duration of a rate limit window",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Open implements ephemeral.EphemeralResource.  It looks up the token by name
+// as the managed resource does, preferring an enabled token to disabled ones
+// of the same name.
+func (e *ephemeralProjectAccessToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralProjectAccessTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	c, ok := e.meta().(*Meta)
+	if !ok || c == nil {
+		resp.Diagnostics.AddError("Provider not configured",
+			"The rollbar provider must be configured before ephemeral resource "+rollbarProjectAccessToken+" is opened.")
+		return
+	}
+	projectID := int(data.ProjectID.ValueInt64())
+	name := data.Name.ValueString()
+	log.Debug().
+		Int("project_id", projectID).
+		Str("name", name).
+		Msg("Opening ephemeral project access token")
+
+	ctx = client.WithDataSource(ctx, rollbarProjectAccessToken)
+	pat, err := findProjectAccessTokenByName(ctx, c.ProjectAccessTokens, projectID, name)
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiags(diagFromErr(err))...)
+		return
+	}
+	data.AccessToken = types.StringValue(pat.AccessToken)
+	data.Scopes = make([]string, 0, len(pat.Scopes))
+	for _, s := range pat.Scopes {
+		data.Scopes = append(data.Scopes, string(s))
+	}
+	data.Status = types.StringValue(string(pat.Status))
+	data.RateLimitWindowCount = types.Int64Value(int64(pat.RateLimitWindowCount))
+	data.RateLimitWindowSize = types.Int64Value(int64(pat.RateLimitWindowSize))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
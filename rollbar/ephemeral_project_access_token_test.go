@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rollbar/terraform-provider-rollbar/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProviderServerSchema checks that the muxed provider servers agree on
+// the provider schema, and that the ephemeral resource is served.
+func TestProviderServerSchema(t *testing.T) {
+	ctx := context.Background()
+	server, err := ProviderServer(ctx)
+	require.NoError(t, err)
+	resp, err := server().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	for _, d := range resp.Diagnostics {
+		assert.NotEqual(t, tfprotov5.DiagnosticSeverityError, d.Severity, "%s: %s", d.Summary, d.Detail)
+	}
+	assert.Contains(t, resp.ResourceSchemas, rollbarProjectAccessToken)
+	assert.Contains(t, resp.EphemeralResourceSchemas, rollbarProjectAccessToken)
+}
+
+// TestEphemeralProjectAccessTokenOpen checks that the ephemeral resource
+// returns the enabled token of the given name.
+func TestEphemeralProjectAccessTokenOpen(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	meta := NewMeta(fake, fake)
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	var want client.ProjectAccessToken
+	for _, status := range []client.Status{client.StatusDisabled, client.StatusEnabled} {
+		want, err = fake.CreateProjectAccessToken(ctx, client.ProjectAccessTokenCreateArgs{
+			ProjectID: p.ID,
+			Name:      "ci",
+			Scopes:    []client.Scope{client.ScopePostServerItem},
+			Status:    status,
+		})
+		require.NoError(t, err)
+	}
+
+	e := &ephemeralProjectAccessToken{meta: func() interface{} { return meta }}
+	var sresp ephemeral.SchemaResponse
+	e.Schema(ctx, ephemeral.SchemaRequest{}, &sresp)
+	require.False(t, sresp.Diagnostics.HasError(), sresp.Diagnostics)
+	s := sresp.Schema
+	typ := s.Type().TerraformType(ctx)
+
+	// open opens the ephemeral resource for the token named name.
+	open := func(name string) (ephemeralProjectAccessTokenModel, ephemeral.OpenResponse) {
+		config := map[string]tftypes.Value{}
+		for k, a := range typ.(tftypes.Object).AttributeTypes {
+			config[k] = tftypes.NewValue(a, nil)
+		}
+		config["project_id"] = tftypes.NewValue(tftypes.Number, p.ID)
+		config["name"] = tftypes.NewValue(tftypes.String, name)
+		req := ephemeral.OpenRequest{Config: tfsdk.Config{Schema: s, Raw: tftypes.NewValue(typ, config)}}
+		resp := ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: s, Raw: tftypes.NewValue(typ, nil)}}
+		e.Open(ctx, req, &resp)
+		var data ephemeralProjectAccessTokenModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.Result.Get(ctx, &data).HasError())
+		}
+		return data, resp
+	}
+
+	data, resp := open("ci")
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, want.AccessToken, data.AccessToken.ValueString())
+	assert.Equal(t, "enabled", data.Status.ValueString())
+	assert.Equal(t, []string{"post_server_item"}, data.Scopes)
+
+	_, resp = open("missing")
+	assert.True(t, resp.Diagnostics.HasError())
+
+	e = &ephemeralProjectAccessToken{meta: func() interface{} { return nil }}
+	_, resp = open("ci")
+	assert.True(t, resp.Diagnostics.HasError())
+}
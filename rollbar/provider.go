@@ -25,8 +25,16 @@ package rollbar
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mitchellh/mapstructure"
@@ -35,10 +43,67 @@ import (
 
 const schemaKeyToken = "api_key"
 const projectKeyToken = "project_api_key"
+const schemaKeyProjectTokens = "project_api_keys"
+const schemaKeyDeriveProjectTokens = "derive_project_tokens"
 const schemaKeyBaseURL = "api_url"
+const schemaKeyMaxConcurrentRequests = "max_concurrent_requests"
+const schemaKeyRequestTimeout = "request_timeout"
+const schemaKeyMaxRetries = "max_retries"
+const schemaKeyRetryWaitMin = "retry_wait_min"
+const schemaKeyRetryWaitMax = "retry_wait_max"
+const schemaKeyRetryableStatusCodes = "retryable_status_codes"
+const schemaKeyTracingEnabled = "tracing_enabled"
+const schemaKeyHARFile = "har_file"
+const schemaKeyProxyURL = "proxy_url"
+const schemaKeyReadOnly = "read_only"
+const schemaKeyAllowedAccountIDs = "allowed_account_ids"
+const schemaKeySkipTokenValidation = "skip_token_validation"
+const schemaKeyForbiddenAccountIDs = "forbidden_account_ids"
+const schemaKeyCACert = "ca_cert"
+const schemaKeyClientCert = "client_cert"
+const schemaKeyClientKey = "client_key"
+const schemaKeyInsecureSkipVerify = "insecure_skip_verify"
+
+// envRetryableStatusCodes is the environment variable holding a
+// comma-separated default for schemaKeyRetryableStatusCodes.  The SDK does not
+// support DefaultFunc on set attributes.
+const envRetryableStatusCodes = "ROLLBAR_RETRYABLE_STATUS_CODES"
+
+// apiStats counts the API requests made by every provider instance in this
+// process.
+var apiStats = client.NewStats()
+
+// APIStats returns the counts of API requests made by the provider in this
+// process so far.
+func APIStats() *client.Stats {
+	return apiStats
+}
+
+// harRecorders holds the HAR recorder for each HAR file path, so that every
+// provider instance in this process recording to the same file shares one.
+var harRecorders = struct {
+	sync.Mutex
+	byPath map[string]*client.HARRecorder
+}{byPath: make(map[string]*client.HARRecorder)}
+
+// harRecorder returns the HAR recorder for the file at path.
+func harRecorder(path string) (*client.HARRecorder, error) {
+	harRecorders.Lock()
+	defer harRecorders.Unlock()
+	if r, ok := harRecorders.byPath[path]; ok {
+		return r, nil
+	}
+	r, err := client.NewHARRecorder(path)
+	if err != nil {
+		return nil, err
+	}
+	harRecorders.byPath[path] = r
+	return r, nil
+}
 
 // Provider is a Terraform provider for Rollbar.
 func Provider() *schema.Provider {
+	defaults := client.DefaultOptions()
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			schemaKeyToken: {
@@ -53,12 +118,136 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_PROJECT_API_KEY", nil),
 				Description: "Rollbar API authentication token (project level). Value will be sourced from environment variable `ROLLBAR_PROJECT_API_KEY` if set.",
 			},
+			schemaKeyProjectTokens: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of Rollbar project IDs to access tokens of those projects.  Used by resources whose `project_id` is set, so one provider can manage notifications, integrations and service links in many projects.",
+			},
+			schemaKeyDeriveProjectTokens: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_DERIVE_PROJECT_TOKENS", false),
+				Description: "Find or create, with `api_key`, an access token for each project named by the `project_id` of a resource and not in `project_api_keys`.  Defaults to false.  Value will be sourced from environment variable `ROLLBAR_DERIVE_PROJECT_TOKENS` if set.",
+			},
 			schemaKeyBaseURL: {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_API_URL", client.DefaultBaseURL),
 				Description: "Base URL for the Rollbar API.  Defaults to https://api.rollbar.com.  Value will be sourced from environment variable `ROLLBAR_API_URL` if set.",
 			},
+			schemaKeyMaxConcurrentRequests: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_MAX_CONCURRENT_REQUESTS", 0),
+				Description: "Maximum number of concurrent requests to the Rollbar API, per API key.  Defaults to 0 (unlimited).  Value will be sourced from environment variable `ROLLBAR_MAX_CONCURRENT_REQUESTS` if set.",
+			},
+			schemaKeyRequestTimeout: {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("ROLLBAR_REQUEST_TIMEOUT", int(defaults.Timeout/time.Second)),
+				ValidateDiagFunc: validateNonNegative,
+				Description:      "Timeout in seconds for each request to the Rollbar API.  Defaults to 30.  0 means no timeout.  Value will be sourced from environment variable `ROLLBAR_REQUEST_TIMEOUT` if set.",
+			},
+			schemaKeyMaxRetries: {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("ROLLBAR_MAX_RETRIES", defaults.MaxRetries),
+				ValidateDiagFunc: validateNonNegative,
+				Description:      "Number of times a failed request to the Rollbar API is retried.  Defaults to 4.  Value will be sourced from environment variable `ROLLBAR_MAX_RETRIES` if set.",
+			},
+			schemaKeyRetryWaitMin: {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("ROLLBAR_RETRY_WAIT_MIN", int(defaults.MinBackoff/time.Second)),
+				ValidateDiagFunc: validateNonNegative,
+				Description:      "Minimum time in seconds to wait before retrying a failed request.  Defaults to 8.  Value will be sourced from environment variable `ROLLBAR_RETRY_WAIT_MIN` if set.",
+			},
+			schemaKeyRetryWaitMax: {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("ROLLBAR_RETRY_WAIT_MAX", int(defaults.MaxBackoff/time.Second)),
+				ValidateDiagFunc: validateNonNegative,
+				Description:      "Maximum time in seconds to wait before retrying a failed request.  Defaults to 50.  Rate limited requests wait until the rate limit resets instead.  Value will be sourced from environment variable `ROLLBAR_RETRY_WAIT_MAX` if set.",
+			},
+			schemaKeyRetryableStatusCodes: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "HTTP response status codes on which a request is retried.  Defaults to 429, 500 and 502.  A 404 is also retried when reading back a newly created project, team or access token.  Value will be sourced from environment variable `ROLLBAR_RETRYABLE_STATUS_CODES`, as a comma-separated list, if set.",
+			},
+			schemaKeySkipTokenValidation: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_SKIP_TOKEN_VALIDATION", false),
+				Description: "Skip checking `api_key` and `project_api_key` against the Rollbar API when the provider is configured.  Defaults to false.  Value will be sourced from environment variable `ROLLBAR_SKIP_TOKEN_VALIDATION` if set.",
+			},
+			schemaKeyAllowedAccountIDs: {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeInt},
+				ConflictsWith: []string{schemaKeyForbiddenAccountIDs},
+				Description:   "IDs of the Rollbar accounts the provider may manage.  If set, the provider refuses to run unless `api_key` belongs to one of them and every project access token can be found with `api_key`.",
+			},
+			schemaKeyForbiddenAccountIDs: {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeInt},
+				ConflictsWith: []string{schemaKeyAllowedAccountIDs},
+				Description:   "IDs of Rollbar accounts the provider must not manage.  If set, the provider refuses to run if `api_key` belongs to one of them, or a project access token cannot be found with `api_key`.",
+			},
+			schemaKeyReadOnly: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_READ_ONLY", false),
+				Description: "Refuse to send any request that could change anything in Rollbar, logging it instead, so that `terraform plan` can run safely with audit credentials and an accidental apply fails without side effects.  Defaults to false.  Value will be sourced from environment variable `ROLLBAR_READ_ONLY` if set.",
+			},
+			schemaKeyProxyURL: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_PROXY_URL", ""),
+				Description: "URL of an HTTP(S) proxy through which to send all requests to the Rollbar API.  Overrides the `HTTPS_PROXY` environment variable.  Value will be sourced from environment variable `ROLLBAR_PROXY_URL` if set.",
+			},
+			schemaKeyCACert: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_CA_CERT", ""),
+				Description: "PEM encoded CA certificates to trust in addition to the system's, e.g. that of a TLS-inspecting proxy.  Either the PEM text itself or the path of a file holding it.  Value will be sourced from environment variable `ROLLBAR_CA_CERT` if set.",
+			},
+			schemaKeyClientCert: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ROLLBAR_CLIENT_CERT", ""),
+				RequiredWith: []string{schemaKeyClientKey},
+				Description:  "PEM encoded client certificate for mutual TLS, or the path of a file holding it.  Requires `client_key`.  Value will be sourced from environment variable `ROLLBAR_CLIENT_CERT` if set.",
+			},
+			schemaKeyClientKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("ROLLBAR_CLIENT_KEY", ""),
+				RequiredWith: []string{schemaKeyClientCert},
+				Description:  "PEM encoded private key of `client_cert`, or the path of a file holding it.  Value will be sourced from environment variable `ROLLBAR_CLIENT_KEY` if set.",
+			},
+			schemaKeyInsecureSkipVerify: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_INSECURE_SKIP_VERIFY", false),
+				Description: "Skip verification of the Rollbar API's TLS certificate.  Only for testing against an on-premises Rollbar.  Defaults to false.  Value will be sourced from environment variable `ROLLBAR_INSECURE_SKIP_VERIFY` if set.",
+			},
+			schemaKeyHARFile: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ROLLBAR_HAR_FILE", ""),
+				Description: "Path of an HTTP Archive (HAR) file in which to record every request to the Rollbar API and its response, with access tokens and other secrets masked.  Entries are added to the file if it already exists.  Value will be sourced from environment variable `ROLLBAR_HAR_FILE` if set.",
+			},
+			schemaKeyTracingEnabled: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: tracingEnabledDefault,
+				Description: "Emit an OpenTelemetry span for every request to the Rollbar API, exported over OTLP/HTTP as configured by the standard `OTEL_EXPORTER_OTLP_*` environment variables.  Defaults to true if environment variable `OTEL_TRACES_EXPORTER` is `otlp` and `OTEL_SDK_DISABLED` is not `true`, otherwise false.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			rollbarProject:            resourceProject(),
@@ -87,9 +276,346 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	token := d.Get(schemaKeyToken).(string)
 	projectToken := d.Get(projectKeyToken).(string)
 	baseURL := d.Get(schemaKeyBaseURL).(string)
-	c := client.NewClient(baseURL, token)
-	pc := client.NewClient(baseURL, projectToken)
-	return map[string]*client.RollbarAPIClient{schemaKeyToken: c, projectKeyToken: pc}, diags
+	opts, err := clientOptions(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	projectTokens, err := projectTokenMap(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	c := client.NewClientWithOptions(baseURL, token, opts)
+	pc := client.NewClientWithOptions(baseURL, projectToken, opts)
+	m := NewMeta(c, pc)
+	projectAPIs := make(map[int]client.RollbarAPI, len(projectTokens))
+	for id, t := range projectTokens {
+		projectAPIs[id] = client.NewClientWithOptions(baseURL, t, opts)
+		m.SetProjectAPI(id, projectAPIs[id])
+	}
+	derive := d.Get(schemaKeyDeriveProjectTokens).(bool)
+	if derive {
+		if token == "" {
+			return nil, diag.Errorf("%s requires %s", schemaKeyDeriveProjectTokens, schemaKeyToken)
+		}
+		scopes := []client.Scope{client.ScopeRead, client.ScopeWrite}
+		if opts.ReadOnly {
+			scopes = []client.Scope{client.ScopeRead}
+		}
+		m.DeriveProjectTokens(func(t string) client.RollbarAPI {
+			return client.NewClientWithOptions(baseURL, t, opts)
+		}, scopes)
+	}
+	var tv tokenValidation
+	if !d.Get(schemaKeySkipTokenValidation).(bool) {
+		var tvDiags diag.Diagnostics
+		tv, tvDiags = validateTokens(ctx, tokenConfig{
+			token:               token,
+			projectToken:        projectToken,
+			api:                 c,
+			projectAPI:          pc,
+			projectTokens:       projectTokens,
+			projectAPIs:         projectAPIs,
+			deriveProjectTokens: derive,
+		})
+		diags = append(diags, tvDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+	allowed := intSet(d, schemaKeyAllowedAccountIDs)
+	forbidden := intSet(d, schemaKeyForbiddenAccountIDs)
+	diags = append(diags, checkAccount(ctx, m, tv.accountID, allowed, forbidden)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	diags = append(diags, checkProjectTokenAccounts(ctx, c, pc, projectToken, projectTokens, allowed, forbidden)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	return m, diags
+}
+
+// Meta is the provider meta value passed to every resource and data source.
+// Each field exposes one domain of the Rollbar API, so provider logic can be
+// tested against a fake such as clienttest.Fake.
+type Meta struct {
+	Projects            client.ProjectsAPI
+	ProjectAccessTokens client.ProjectAccessTokensAPI
+	Teams               client.TeamsAPI
+	Users               client.UsersAPI
+	Invitations         client.InvitationsAPI
+	Notifications       client.NotificationsAPI
+	Integrations        client.IntegrationsAPI
+	ServiceLinks        client.ServiceLinksAPI
+
+	// projects holds the Meta for each project with its own access token.
+	projects *projectMetas
+}
+
+// projectMetas holds the Meta for each project with its own access token.
+type projectMetas struct {
+	sync.Mutex
+	byID map[int]*Meta
+
+	// deriving holds the derivation in progress for each project, which
+	// other callers wait for rather than derive another token.
+	deriving map[int]*projectDerivation
+
+	// newAPI, if not nil, returns a client authenticated with a project
+	// access token.  ForProject then derives a token with the required scopes
+	// for each project not in byID.
+	newAPI func(token string) client.RollbarAPI
+	scopes []client.Scope
+}
+
+// projectDerivation is the derivation of an access token for one project.
+// Its result is set before done is closed.
+type projectDerivation struct {
+	done chan struct{}
+	meta *Meta
+	err  error
+}
+
+// NewMeta returns a Meta that uses api, authenticated with an account access
+// token, for account level operations, and projectAPI, authenticated with a
+// project access token, for project level operations.
+func NewMeta(api, projectAPI client.RollbarAPI) *Meta {
+	return &Meta{
+		Projects:            api,
+		ProjectAccessTokens: api,
+		Teams:               api,
+		Users:               api,
+		Invitations:         api,
+		Notifications:       projectAPI,
+		Integrations:        projectAPI,
+		ServiceLinks:        projectAPI,
+		projects: &projectMetas{
+			byID:     make(map[int]*Meta),
+			deriving: make(map[int]*projectDerivation),
+		},
+	}
+}
+
+// withProjectAPI returns a copy of m that uses projectAPI for project level
+// operations.
+func (m *Meta) withProjectAPI(projectAPI client.RollbarAPI) *Meta {
+	pm := *m
+	pm.projects = nil
+	pm.Notifications = projectAPI
+	pm.Integrations = projectAPI
+	pm.ServiceLinks = projectAPI
+	return &pm
+}
+
+// SetProjectAPI makes resources in the project with the given ID use
+// projectAPI, authenticated with an access token of that project, for project
+// level operations.
+func (m *Meta) SetProjectAPI(projectID int, projectAPI client.RollbarAPI) {
+	m.projects.Lock()
+	defer m.projects.Unlock()
+	m.projects.byID[projectID] = m.withProjectAPI(projectAPI)
+}
+
+// DeriveProjectTokens makes ForProject find or create, with the account
+// access token, an access token with the given scopes for each project not
+// set with SetProjectAPI.  newAPI returns a client authenticated with such a
+// token.
+func (m *Meta) DeriveProjectTokens(newAPI func(token string) client.RollbarAPI, scopes []client.Scope) {
+	m.projects.Lock()
+	defer m.projects.Unlock()
+	m.projects.newAPI = newAPI
+	m.projects.scopes = scopes
+}
+
+// ForProject returns the Meta for resources in the project with the given ID.
+// A zero ID means the project of the provider's project_api_key.  Derived
+// tokens are cached for the life of m.  A token is derived once however many
+// resources of the project ask for it at the same time, without holding up
+// resources of other projects.
+func (m *Meta) ForProject(ctx context.Context, projectID int) (*Meta, error) {
+	if projectID == 0 {
+		return m, nil
+	}
+	m.projects.Lock()
+	if pm, ok := m.projects.byID[projectID]; ok {
+		m.projects.Unlock()
+		return pm, nil
+	}
+	newAPI, scopes := m.projects.newAPI, m.projects.scopes
+	if newAPI == nil {
+		m.projects.Unlock()
+		return nil, fmt.Errorf("no access token for project %d in provider argument %s, and %s is not enabled",
+			projectID, schemaKeyProjectTokens, schemaKeyDeriveProjectTokens)
+	}
+	if pd, ok := m.projects.deriving[projectID]; ok {
+		m.projects.Unlock()
+		select {
+		case <-pd.done:
+			return pd.meta, pd.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	pd := &projectDerivation{done: make(chan struct{})}
+	m.projects.deriving[projectID] = pd
+	m.projects.Unlock()
+
+	token, err := deriveProjectToken(ctx, m.ProjectAccessTokens, projectID, scopes)
+	if err != nil {
+		pd.err = fmt.Errorf("error deriving access token for project %d: %w", projectID, err)
+	} else {
+		pd.meta = m.withProjectAPI(newAPI(token))
+	}
+
+	m.projects.Lock()
+	delete(m.projects.deriving, projectID)
+	if pd.err == nil {
+		m.projects.byID[projectID] = pd.meta
+	}
+	m.projects.Unlock()
+	close(pd.done)
+	return pd.meta, pd.err
+}
+
+// projectMeta returns the Meta for the project set in the project_id argument
+// of resource d.
+func projectMeta(ctx context.Context, d *schema.ResourceData, m interface{}) (*Meta, diag.Diagnostics) {
+	pm, err := m.(*Meta).ForProject(ctx, d.Get(schemaKeyProjectID).(int))
+	if err != nil {
+		return nil, diagFromErr(err)
+	}
+	return pm, nil
+}
+
+// projectTokenMap reads the project_api_keys argument, keyed by project ID.
+func projectTokenMap(d *schema.ResourceData) (map[int]string, error) {
+	tokens := make(map[int]string)
+	for k, v := range d.Get(schemaKeyProjectTokens).(map[string]interface{}) {
+		id, err := strconv.Atoi(k)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid project ID %q in %s", k, schemaKeyProjectTokens)
+		}
+		tokens[id] = v.(string)
+	}
+	return tokens, nil
+}
+
+// clientOptions builds the API client options from the provider
+// configuration.
+func clientOptions(d *schema.ResourceData) (client.Options, error) {
+	opts := client.DefaultOptions()
+	opts.Stats = apiStats
+	opts.ReadOnly = d.Get(schemaKeyReadOnly).(bool)
+	opts.RequireToken = true
+	opts.MaxConcurrentRequests = d.Get(schemaKeyMaxConcurrentRequests).(int)
+	opts.Timeout = time.Duration(d.Get(schemaKeyRequestTimeout).(int)) * time.Second
+	opts.MaxRetries = d.Get(schemaKeyMaxRetries).(int)
+	opts.MinBackoff = time.Duration(d.Get(schemaKeyRetryWaitMin).(int)) * time.Second
+	opts.MaxBackoff = time.Duration(d.Get(schemaKeyRetryWaitMax).(int)) * time.Second
+	if opts.MaxBackoff < opts.MinBackoff {
+		return opts, fmt.Errorf("%s (%d) must not be less than %s (%d)",
+			schemaKeyRetryWaitMax, opts.MaxBackoff/time.Second,
+			schemaKeyRetryWaitMin, opts.MinBackoff/time.Second)
+	}
+
+	if set, ok := d.GetOk(schemaKeyRetryableStatusCodes); ok {
+		opts.RetryableStatusCodes = nil
+		for _, v := range set.(*schema.Set).List() {
+			opts.RetryableStatusCodes = append(opts.RetryableStatusCodes, v.(int))
+		}
+	} else if env, ok := os.LookupEnv(envRetryableStatusCodes); ok {
+		opts.RetryableStatusCodes = nil
+		for _, f := range strings.Split(env, ",") {
+			f = strings.TrimSpace(f)
+			if f == "" {
+				continue
+			}
+			code, err := strconv.Atoi(f)
+			if err != nil {
+				return opts, fmt.Errorf("invalid status code %q in %s", f, envRetryableStatusCodes)
+			}
+			opts.RetryableStatusCodes = append(opts.RetryableStatusCodes, code)
+		}
+	}
+	for _, code := range opts.RetryableStatusCodes {
+		if code < 100 || code > 599 {
+			return opts, fmt.Errorf("invalid HTTP status code %d in %s", code, schemaKeyRetryableStatusCodes)
+		}
+	}
+
+	tc, err := transportConfig(d)
+	if err != nil {
+		return opts, err
+	}
+	if !tc.IsZero() {
+		opts.Transport, err = client.NewTransport(tc)
+		if err != nil {
+			return opts, err
+		}
+	}
+
+	if path := d.Get(schemaKeyHARFile).(string); path != "" {
+		r, err := harRecorder(path)
+		if err != nil {
+			return opts, fmt.Errorf("error opening %s: %w", schemaKeyHARFile, err)
+		}
+		opts.HAR = r
+	}
+
+	if d.Get(schemaKeyTracingEnabled).(bool) {
+		tp, err := tracerProvider()
+		if err != nil {
+			return opts, fmt.Errorf("error setting up OpenTelemetry tracing: %w", err)
+		}
+		opts.TracerProvider = tp
+	}
+	return opts, nil
+}
+
+// transportConfig builds the API client transport configuration from the
+// provider configuration, reading any certificates and keys given as file
+// paths.
+func transportConfig(d *schema.ResourceData) (client.TransportConfig, error) {
+	tc := client.TransportConfig{
+		ProxyURL:           d.Get(schemaKeyProxyURL).(string),
+		InsecureSkipVerify: d.Get(schemaKeyInsecureSkipVerify).(bool),
+	}
+	for key, dst := range map[string]*[]byte{
+		schemaKeyCACert:     &tc.CACertPEM,
+		schemaKeyClientCert: &tc.ClientCertPEM,
+		schemaKeyClientKey:  &tc.ClientKeyPEM,
+	} {
+		pem, err := readPEM(d.Get(key).(string))
+		if err != nil {
+			return tc, fmt.Errorf("error reading %s: %w", key, err)
+		}
+		*dst = pem
+	}
+	return tc, nil
+}
+
+// readPEM returns v itself if it is PEM encoded text, or else the content of
+// the file at path v.  An empty v yields nil.
+func readPEM(v string) ([]byte, error) {
+	if v == "" {
+		return nil, nil
+	}
+	if strings.Contains(v, "-----BEGIN ") {
+		return []byte(v), nil
+	}
+	return os.ReadFile(v) // #nosec
+}
+
+// validateNonNegative checks that an integer attribute is zero or greater.
+func validateNonNegative(v interface{}, p cty.Path) diag.Diagnostics {
+	if v.(int) < 0 {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Value must not be negative",
+			AttributePath: p,
+		}}
+	}
+	return nil
 }
 
 /*
@@ -111,6 +637,57 @@ func (es *errSetter) Set(key string, value interface{}) {
 
 */
 
+// diagFromErr converts an error to Terraform diagnostics.  Rollbar API errors
+// are annotated with the failing endpoint and, where possible, a hint on how to
+// fix the problem.
+func diagFromErr(err error) diag.Diagnostics {
+	var mte *client.MissingTokenError
+	if errors.As(err, &mte) {
+		name := mte.Resource
+		if name == "" {
+			name = mte.DataSource
+		}
+		detail := err.Error() + "."
+		if name != "" {
+			key := tokenKeyFor(name)
+			detail = fmt.Sprintf("%s needs a Rollbar access token in provider argument %s or environment variable %s, which is not set. %s",
+				name, key, tokenEnvVars[key], detail)
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Missing Rollbar API token",
+			Detail:   detail,
+		}}
+	}
+	if errors.Is(err, client.ErrReadOnly) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Rollbar provider is read-only",
+			Detail:   fmt.Sprintf("%s. Nothing was changed in Rollbar. Set provider argument %s to false to allow changes.", err, schemaKeyReadOnly),
+		}}
+	}
+	var ae *client.APIError
+	if !errors.As(err, &ae) {
+		return diag.FromErr(err)
+	}
+	summary := ae.Message
+	if summary == "" {
+		summary = http.StatusText(ae.StatusCode)
+	}
+	detail := fmt.Sprintf("%s %s returned HTTP status %d.", ae.Method, ae.URL, ae.StatusCode)
+	if ae.RequestID != "" {
+		detail += fmt.Sprintf(" Request ID: %s.", ae.RequestID)
+	}
+	if hint := ae.Hint(); hint != "" {
+		detail += fmt.Sprintf(" Hint: %s.", hint)
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   detail,
+	}}
+}
+
 // mustSet sets a value for a key in a schema, or panics on error.
 func mustSet(d *schema.ResourceData, key string, value interface{}) {
 	err := d.Set(key, value)
@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	pschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServer returns the provider server to serve: Provider, muxed with
+// a provider built on terraform-plugin-framework for ephemeral resources,
+// which SDKv2 does not support.
+func ProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	p := Provider()
+	mux, err := tf5muxserver.NewMuxServer(ctx,
+		p.GRPCProvider,
+		providerserver.NewProtocol5(&frameworkProvider{sdk: p}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return mux.ProviderServer, nil
+}
+
+// frameworkProvider serves the ephemeral resources.  Muxed providers must
+// have the same provider schema, so it takes its schema from the SDKv2
+// provider sdk.  Only sdk is configured; ephemeral resources use its Meta.
+type frameworkProvider struct {
+	sdk *schema.Provider
+}
+
+var _ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+
+// Metadata implements provider.Provider.
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "rollbar"
+}
+
+// Schema implements provider.Provider.
+func (p *frameworkProvider) Schema(ctx context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	s, err := frameworkProviderSchema(ctx, p.sdk)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to build provider schema", err.Error())
+		return
+	}
+	resp.Schema = s
+}
+
+// Configure implements provider.Provider.  The SDKv2 provider is configured
+// with the same configuration, so there is nothing to do.
+func (p *frameworkProvider) Configure(context.Context, provider.ConfigureRequest, *provider.ConfigureResponse) {
+}
+
+// Resources implements provider.Provider.
+func (p *frameworkProvider) Resources(context.Context) []func() resource.Resource {
+	return nil
+}
+
+// DataSources implements provider.Provider.
+func (p *frameworkProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// EphemeralResources implements provider.ProviderWithEphemeralResources.
+func (p *frameworkProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource {
+			return &ephemeralProjectAccessToken{meta: p.sdk.Meta}
+		},
+	}
+}
+
+// frameworkProviderSchema returns the provider schema of the SDKv2 provider
+// p, as a terraform-plugin-framework schema.
+func frameworkProviderSchema(ctx context.Context, p *schema.Provider) (pschema.Schema, error) {
+	var s pschema.Schema
+	resp, err := p.GRPCProvider().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return s, err
+	}
+	block := resp.Provider.Block
+	if len(block.BlockTypes) > 0 {
+		return s, fmt.Errorf("nested blocks in provider schema are not supported")
+	}
+	s.Attributes = make(map[string]pschema.Attribute, len(block.Attributes))
+	for _, a := range block.Attributes {
+		fa, err := frameworkProviderAttribute(a)
+		if err != nil {
+			return s, fmt.Errorf("provider argument %s: %w", a.Name, err)
+		}
+		s.Attributes[a.Name] = fa
+	}
+	return s, nil
+}
+
+// frameworkProviderAttribute returns SDKv2 provider argument a as a
+// terraform-plugin-framework attribute.
+func frameworkProviderAttribute(a *tfprotov5.SchemaAttribute) (pschema.Attribute, error) {
+	var desc, mdDesc, deprecation string
+	if a.DescriptionKind == tfprotov5.StringKindMarkdown {
+		mdDesc = a.Description
+	} else {
+		desc = a.Description
+	}
+	if a.Deprecated {
+		deprecation = "Deprecated"
+	}
+	switch {
+	case a.Type.Is(tftypes.String):
+		return pschema.StringAttribute{Description: desc, MarkdownDescription: mdDesc, Required: a.Required,
+			Optional: a.Optional, Sensitive: a.Sensitive, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Bool):
+		return pschema.BoolAttribute{Description: desc, MarkdownDescription: mdDesc, Required: a.Required,
+			Optional: a.Optional, Sensitive: a.Sensitive, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Number):
+		return pschema.NumberAttribute{Description: desc, MarkdownDescription: mdDesc, Required: a.Required,
+			Optional: a.Optional, Sensitive: a.Sensitive, DeprecationMessage: deprecation}, nil
+	}
+	var elem tftypes.Type
+	switch t := a.Type.(type) {
+	case tftypes.Set:
+		elem = t.ElementType
+	case tftypes.List:
+		elem = t.ElementType
+	case tftypes.Map:
+		elem = t.ElementType
+	default:
+		return nil, fmt.Errorf("unsupported type %s", a.Type)
+	}
+	var elemType attr.Type
+	switch {
+	case elem.Is(tftypes.String):
+		elemType = types.StringType
+	case elem.Is(tftypes.Bool):
+		elemType = types.BoolType
+	case elem.Is(tftypes.Number):
+		elemType = types.NumberType
+	default:
+		return nil, fmt.Errorf("unsupported element type %s", elem)
+	}
+	switch a.Type.(type) {
+	case tftypes.Set:
+		return pschema.SetAttribute{ElementType: elemType, Description: desc, MarkdownDescription: mdDesc,
+			Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, DeprecationMessage: deprecation}, nil
+	case tftypes.List:
+		return pschema.ListAttribute{ElementType: elemType, Description: desc, MarkdownDescription: mdDesc,
+			Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, DeprecationMessage: deprecation}, nil
+	}
+	return pschema.MapAttribute{ElementType: elemType, Description: desc, MarkdownDescription: mdDesc,
+		Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive, DeprecationMessage: deprecation}, nil
+}
+
+// frameworkDiags converts SDKv2 diagnostics to terraform-plugin-framework
+// diagnostics.
+func frameworkDiags(diags diag.Diagnostics) fwdiag.Diagnostics {
+	var fds fwdiag.Diagnostics
+	for _, d := range diags {
+		if d.Severity == diag.Warning {
+			fds.AddWarning(d.Summary, d.Detail)
+			continue
+		}
+		fds.AddError(d.Summary, d.Detail)
+	}
+	return fds
+}
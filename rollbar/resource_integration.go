@@ -52,6 +52,12 @@ func resourceIntegraion() *schema.Resource {
 		DeleteContext: resourceIntegrationDelete,
 
 		Schema: map[string]*schema.Schema{
+			schemaKeyProjectID: {
+				Description: "ID of the project the integration belongs to, whose access token is set in provider argument project_api_keys.  Defaults to the project of provider argument project_api_key.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+			},
 			client.EMAIL: {
 				Description: "Email integration",
 				Type:        schema.TypeSet,
@@ -224,7 +230,7 @@ func setBodyMapFromInterface(integration string, intf interface{}, toDelete bool
 	}
 	return bodyMap
 }
-func resourceIntegrationCreateUpdateDelete(integration string, bodyMap map[string]interface{}, d *schema.ResourceData, m interface{}, action Action) (zerolog.Logger, diag.Diagnostics) {
+func resourceIntegrationCreateUpdateDelete(ctx context.Context, integration string, bodyMap map[string]interface{}, d *schema.ResourceData, m interface{}, action Action) (zerolog.Logger, diag.Diagnostics) {
 	l := log.With().Str("integration", integration).Logger()
 	switch action {
 	case CREATE:
@@ -240,16 +246,19 @@ func resourceIntegrationCreateUpdateDelete(integration string, bodyMap map[strin
 		id = d.Id()
 		l = l.With().Str("id", id).Logger()
 	}
-	c := m.(map[string]*client.RollbarAPIClient)[projectKeyToken]
-	c.SetHeaderResource(rollbarIntegration)
-	intf, err := c.UpdateIntegration(integration, bodyMap)
+	c, diags := projectMeta(ctx, d, m)
+	if diags != nil {
+		return l, diags
+	}
+	ctx = client.WithResource(ctx, rollbarIntegration)
+	intf, err := c.Integrations.UpdateIntegration(ctx, integration, bodyMap)
 
 	if err != nil {
 		l.Err(err).Send()
 		if action == CREATE || action == UPDATE {
 			d.SetId("") // removing from the state
 		}
-		return l, diag.FromErr(err)
+		return l, diagFromErr(err)
 	}
 	var projectID int64
 	switch integration {
@@ -273,7 +282,7 @@ func resourceIntegrationCreateUpdateDelete(integration string, bodyMap map[strin
 			err = errors.New("IDs are not equal")
 			l.Err(err).Send()
 			d.SetId("") // removing from the state
-			return l, diag.FromErr(err)
+			return l, diagFromErr(err)
 		}
 	}
 	if action == CREATE {
@@ -286,11 +295,11 @@ func resourceIntegrationCreate(ctx context.Context, d *schema.ResourceData, m in
 	var err error
 	var integration string
 	if integration, err = resourcePreCheck(d); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	properIntgr := parseSet(integration, d)
 	bodyMap := setBodyMapFromMap(integration, properIntgr, false)
-	l, e := resourceIntegrationCreateUpdateDelete(integration, bodyMap, d, m, CREATE)
+	l, e := resourceIntegrationCreateUpdateDelete(ctx, integration, bodyMap, d, m, CREATE)
 	if e != nil {
 		return e
 	}
@@ -302,11 +311,11 @@ func resourceIntegrationUpdate(ctx context.Context, d *schema.ResourceData, m in
 	var err error
 	var integration string
 	if integration, err = resourcePreCheck(d); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	properIntgr := parseSet(integration, d)
 	bodyMap := setBodyMapFromMap(integration, properIntgr, false)
-	l, e := resourceIntegrationCreateUpdateDelete(integration, bodyMap, d, m, UPDATE)
+	l, e := resourceIntegrationCreateUpdateDelete(ctx, integration, bodyMap, d, m, UPDATE)
 	if e != nil {
 		return e
 	}
@@ -318,11 +327,11 @@ func resourceIntegrationDelete(ctx context.Context, d *schema.ResourceData, m in
 	var err error
 	var integration string
 	if integration, err = resourcePreCheck(d); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	properIntgr := parseSet(integration, d)
 	bodyMap := setBodyMapFromMap(integration, properIntgr, true)
-	l, e := resourceIntegrationCreateUpdateDelete(integration, bodyMap, d, m, DELETE)
+	l, e := resourceIntegrationCreateUpdateDelete(ctx, integration, bodyMap, d, m, DELETE)
 	if e != nil {
 		return e
 	}
@@ -339,18 +348,21 @@ func resourceIntegrationRead(ctx context.Context, d *schema.ResourceData, m inte
 	spl := strings.Split(id, ComplexImportSeparator)
 	integration := spl[1]
 	l.Info().Msg("Reading rollbar_integration resource")
-	c := m.(map[string]*client.RollbarAPIClient)[projectKeyToken]
-	c.SetHeaderResource(rollbarIntegration)
-	intf, err := c.ReadIntegration(integration)
+	c, diags := projectMeta(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	ctx = client.WithResource(ctx, rollbarIntegration)
+	intf, err := c.Integrations.ReadIntegration(ctx, integration)
 
-	if err == client.ErrNotFound {
+	if errors.Is(err, client.ErrNotFound) {
 		d.SetId("")
 		l.Info().Msg("Integration not found - removed from state")
 		return nil
 	}
 	if err != nil {
 		l.Err(err).Msg("error reading rollbar_integration resource")
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	bodyMap := setBodyMapFromInterface(integration, intf, false)
 	mustSet(d, integration, flattenIntegration(integration, bodyMap))
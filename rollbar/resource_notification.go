@@ -25,6 +25,7 @@ package rollbar
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -48,8 +49,19 @@ const (
 
 var emailDailySummaryConfigList = []string{"summary_time", "environments", "send_only_if_data", "min_item_level"}
 
+// CustomNotificationImport imports a notification rule by an ID of the form
+// "channel,id", or "project_id,channel,id" for a rule in a project whose
+// access token is in provider argument project_api_keys.
 func CustomNotificationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	splitID := strings.Split(d.Id(), ComplexImportSeparator)
+	if len(splitID) > 2 {
+		projectID, err := strconv.Atoi(splitID[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid project ID %q in import ID %q", splitID[0], d.Id())
+		}
+		mustSet(d, schemaKeyProjectID, projectID)
+		splitID = splitID[1:]
+	}
 	if len(splitID) > 1 {
 		mustSet(d, "channel", splitID[0])
 		d.SetId(splitID[1])
@@ -209,6 +221,14 @@ func resourceNotification() *schema.Resource {
 					},
 				},
 			},
+
+			// Optional
+			schemaKeyProjectID: {
+				Description: "ID of the project the notification rule belongs to, whose access token is set in provider argument project_api_keys.  Defaults to the project of provider argument project_api_key.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+			},
 		},
 	}
 }
@@ -291,15 +311,18 @@ func resourceNotificationCreate(ctx context.Context, d *schema.ResourceData, m i
 
 	l.Info().Msg("Creating rollbar_notification resource")
 
-	c := m.(map[string]*client.RollbarAPIClient)[projectKeyToken]
-	c.SetHeaderResource(rollbarNotification)
+	c, diags := projectMeta(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	ctx = client.WithResource(ctx, rollbarNotification)
 
-	n, err := c.CreateNotification(channel, filters, trigger, config, status)
+	n, err := c.Notifications.CreateNotification(ctx, channel, filters, trigger, config, status)
 
 	if err != nil {
 		l.Err(err).Send()
 		d.SetId("") // removing from the state
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	l = l.With().Int("id", n.ID).Logger()
 
@@ -320,20 +343,23 @@ func resourceNotificationUpdate(ctx context.Context, d *schema.ResourceData, m i
 
 	l.Info().Msg("Creating rollbar_notification resource")
 
-	c := m.(map[string]*client.RollbarAPIClient)[projectKeyToken]
-	c.SetHeaderResource(rollbarNotification)
-	n, err := c.UpdateNotification(id, channel, filters, trigger, config, status)
+	c, diags := projectMeta(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	ctx = client.WithResource(ctx, rollbarNotification)
+	n, err := c.Notifications.UpdateNotification(ctx, id, channel, filters, trigger, config, status)
 
 	if err != nil {
 		l.Err(err).Send()
 		d.SetId("") // removing from the state
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	if n.ID != id {
 		err = errors.New("IDs are not equal")
 		l.Err(err).Send()
 		d.SetId("") // removing from the state
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	l = l.With().Int("id", n.ID).Logger()
 
@@ -395,18 +421,21 @@ func resourceNotificationRead(ctx context.Context, d *schema.ResourceData, m int
 		Int("id", id).
 		Logger()
 	l.Info().Msg("Reading rollbar_notification resource")
-	c := m.(map[string]*client.RollbarAPIClient)[projectKeyToken]
-	c.SetHeaderResource(rollbarNotification)
-	n, err := c.ReadNotification(id, channel)
+	c, diags := projectMeta(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	ctx = client.WithResource(ctx, rollbarNotification)
+	n, err := c.Notifications.ReadNotification(ctx, id, channel)
 
-	if err == client.ErrNotFound {
+	if errors.Is(err, client.ErrNotFound) {
 		d.SetId("")
 		l.Info().Msg("Notification not found - removed from state")
 		return nil
 	}
 	if err != nil {
 		l.Err(err).Msg("error reading rollbar_notification resource")
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	mustSet(d, "config", flattenConfig(n.Config))
@@ -420,13 +449,16 @@ func resourceNotificationDelete(ctx context.Context, d *schema.ResourceData, m i
 	channel := d.Get("channel").(string)
 	l := log.With().Int("id", id).Logger()
 	l.Info().Msg("Deleting rollbar_notification resource")
-	c := m.(map[string]*client.RollbarAPIClient)[projectKeyToken]
-	c.SetHeaderResource(rollbarNotification)
-	err := c.DeleteNotification(id, channel)
+	c, diags := projectMeta(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	ctx = client.WithResource(ctx, rollbarNotification)
+	err := c.Notifications.DeleteNotification(ctx, id, channel)
 
 	if err != nil {
 		l.Err(err).Msg("Error deleting rollbar_notification resource")
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	l.Debug().Msg("Successfully deleted rollbar_notification resource")
 	return nil
@@ -24,6 +24,7 @@ package rollbar
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -93,19 +94,23 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 	l := log.With().Str("name", name).Logger()
 	l.Info().Msg("Creating new Rollbar project resource")
 
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarProject)
-	p, err := c.CreateProject(name)
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarProject)
+	p, err := c.Projects.CreateProject(ctx, name)
 
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	l.Debug().Interface("project", p).Msg("CreateProject() result")
 	projectID := p.ID
 	l = l.With().Int("project_id", projectID).Logger()
 	d.SetId(strconv.Itoa(projectID))
 
+	// The new project may not be visible to the API straight away, so reads
+	// retry on 404
+	readCtx := client.WithNotFoundRetry(ctx)
+
 	// A set of four default access tokens are automagically created by Rollbar
 	// when creating a new project.  However we only want access tokens that are
 	// explicitly created and managed by Terraform.  Therefore we delete the
@@ -116,10 +121,10 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 		"post_client_item": true,
 		"post_server_item": true,
 	}
-	tokens, err := c.ListProjectAccessTokens(projectID)
+	tokens, err := c.ProjectAccessTokens.ListProjectAccessTokens(readCtx, projectID)
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	for _, t := range tokens {
 		// Sanity check
@@ -127,13 +132,13 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 		if !expected {
 			err = fmt.Errorf("unexpected token name in default tokens")
 			l.Err(err).Send()
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		// Deletion
-		err = c.DeleteProjectAccessToken(projectID, t.AccessToken)
+		err = c.ProjectAccessTokens.DeleteProjectAccessToken(ctx, projectID, t.AccessToken)
 		if err != nil {
 			l.Err(err).Send()
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		l.Debug().
 			Str("name", t.Name).
@@ -145,15 +150,15 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 	for _, teamIDiface := range teamIDsSet.List() {
 		teamID := teamIDiface.(int)
 		l = l.With().Int("team_id", teamID).Logger()
-		err = c.AssignTeamToProject(teamID, projectID)
+		err = c.Teams.AssignTeamToProject(ctx, teamID, projectID)
 		if err != nil {
 			l.Err(err).Send()
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
 	l.Debug().Msg("Successfully created Rollbar project resource")
-	return resourceProjectRead(ctx, d, m)
+	return resourceProjectRead(readCtx, d, m)
 }
 
 func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -163,18 +168,18 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 		Logger()
 	l.Info().Msg("Reading Rollbar project resource")
 
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarProject)
-	proj, err := c.ReadProject(projectID)
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarProject)
+	proj, err := c.Projects.ReadProject(ctx, projectID)
 
-	if err == client.ErrNotFound {
+	if errors.Is(err, client.ErrNotFound) {
 		l.Debug().Msg("Project not found on Rollbar - removing from state")
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	var mProj map[string]interface{}
@@ -185,10 +190,10 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 		mustSet(d, k, v)
 	}
-	teamIDs, err := c.FindProjectTeamIDs(projectID)
+	teamIDs, err := c.Projects.FindProjectTeamIDs(ctx, projectID)
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	mustSet(d, "team_ids", teamIDs)
 
@@ -206,14 +211,14 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		Ints("team_ids", teamIDs).
 		Logger()
 	l.Debug().Msg("Updating rollbar_project resource")
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarProject)
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarProject)
 
-	err := c.UpdateProjectTeams(projectID, teamIDs)
+	err := c.Projects.UpdateProjectTeams(ctx, projectID, teamIDs)
 
 	if err != nil {
 		l.Err(err).Msg("Error updating rollbar_project resource")
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	l.Debug().Msg("Successfully updated rollbar_project resource")
 	return resourceProjectRead(ctx, d, m)
@@ -226,13 +231,13 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interf
 		Int("projectID", projectID).
 		Logger()
 	l.Info().Msg("Deleting rollbar_project resource")
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarProject)
-	err := c.DeleteProject(projectID)
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarProject)
+	err := c.Projects.DeleteProject(ctx, projectID)
 
 	if err != nil {
 		l.Err(err).Msg("Error deleting rollbar_project resource")
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	l.Debug().Msg("Successfully deleted rollbar_project resource")
 	return nil
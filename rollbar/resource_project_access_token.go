@@ -24,8 +24,8 @@ package rollbar
 
 import (
 	"context"
-	"crypto/md5"
-	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -36,10 +36,44 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func getMD5Hash(text string) string {
-	hasher := md5.New()
-	hasher.Write([]byte(text))
-	return hex.EncodeToString(hasher.Sum(nil))
+// projectAccessTokenID returns the resource ID of the project access token
+// with the given name in the given project.  The ID is set when the token is
+// created or imported, and kept if the token is renamed.
+func projectAccessTokenID(projectID int, name string) string {
+	return strconv.Itoa(projectID) + ComplexImportSeparator + name
+}
+
+// resourceProjectAccessTokenV0 is version 0 of the resource, whose ID was an
+// MD5 hash of the access token.  Only the ID has changed since.
+func resourceProjectAccessTokenV0() *schema.Resource {
+	return &schema.Resource{Schema: projectAccessTokenSchema()}
+}
+
+// upgradeProjectAccessTokenStateV0 replaces the MD5 hash ID of version 0 with
+// an ID made of the project ID and token name.  The token is unchanged.
+func upgradeProjectAccessTokenStateV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return nil, nil
+	}
+	name, _ := rawState["name"].(string)
+	var projectID int
+	switch v := rawState["project_id"].(type) {
+	case float64:
+		projectID = int(v)
+	case json.Number:
+		id, err := v.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid project_id %q in state: %w", v, err)
+		}
+		projectID = int(id)
+	case int:
+		projectID = v
+	}
+	if projectID == 0 || name == "" {
+		return nil, fmt.Errorf("unable to upgrade project access token state without project_id and name")
+	}
+	rawState["id"] = projectAccessTokenID(projectID, name)
+	return rawState, nil
 }
 
 func resourceProjectAccessToken() *schema.Resource {
@@ -48,125 +82,179 @@ func resourceProjectAccessToken() *schema.Resource {
 		ReadContext:   resourceProjectAccessTokenRead,
 		DeleteContext: resourceProjectAccessTokenDelete,
 		UpdateContext: resourceProjectAccessTokenUpdate,
+		CustomizeDiff: customizeProjectAccessTokenDiff,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceProjectAccessTokenImporter,
 		},
 
-		Schema: map[string]*schema.Schema{
-			// Required fields
-			"project_id": {
-				Description: "ID of the Rollbar project to which this token belongs",
-				Type:        schema.TypeInt,
-				Required:    true,
-				ForceNew:    true,
-			},
-			"name": {
-				Description: "The human readable name for the token",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true, // FIXME: https://github.com/rollbar/terraform-provider-rollbar/issues/41
-			},
-			"scopes": {
-				Description: `List of access scopes granted to the token.  Possible values are "read", "write", "post_server_item", and "post_client_server".`,
-				Type:        schema.TypeSet,
-				Required:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				ForceNew:    true, // FIXME: https://github.com/rollbar/terraform-provider-rollbar/issues/41
-			},
-
-			// Optional fields
-			"status": {
-				Description: `Status of the token.  Possible values are "enabled" and "disabled"`,
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "enabled",
-				ForceNew:    true, // FIXME: https://github.com/rollbar/terraform-provider-rollbar/issues/41
-			},
-			"rate_limit_window_count": {
-				Description: "Total number of calls allowed within the rate limit window",
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     0,
-			},
-			"rate_limit_window_size": {
-				Description: "Total number of seconds that makes up the rate limit window",
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     0,
-			},
-
-			// Computed fields
-			"access_token": {
-				Description: "Access token for Rollbar API",
-				Type:        schema.TypeString,
-				Computed:    true,
-				Sensitive:   true,
-			},
-			"date_created": {
-				Description: "Date the project was created",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			"date_modified": {
-				Description: "Date the project was last modified",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			"cur_rate_limit_window_count": {
-				Description: "Count of calls in the current window",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
-			"cur_rate_limit_window_start": {
-				Description: "Time when the current window began",
-				Type:        schema.TypeInt,
-				Computed:    true,
-			},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{{
+			Version: 0,
+			Type:    resourceProjectAccessTokenV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeProjectAccessTokenStateV0,
+		}},
+
+		Schema: projectAccessTokenSchema(),
+	}
+}
+
+// projectAccessTokenSchema returns the schema of the project access token
+// resource.
+func projectAccessTokenSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Required fields
+		"project_id": {
+			Description: "ID of the Rollbar project to which this token belongs",
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+		},
+		"name": {
+			Description: "The human readable name for the token",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"scopes": {
+			Description: `List of access scopes granted to the token.  Possible values are "read", "write", "post_server_item", and "post_client_item".`,
+			Type:        schema.TypeSet,
+			Required:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+
+		// Optional fields
+		"status": {
+			Description: `Status of the token.  Possible values are "enabled" and "disabled"`,
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "enabled",
+		},
+		"rate_limit_window_count": {
+			Description: "Total number of calls allowed within the rate limit window",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     0,
+		},
+		"rate_limit_window_size": {
+			Description: "Total number of seconds that makes up the rate limit window",
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     0,
+		},
+		"keepers": {
+			Description: "Arbitrary map of values that, when changed, rotates the token",
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"rotate_after": {
+			Description:      `Age after which the token is rotated on the next apply, e.g. "90d" or "2160h"`,
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validateRotationDuration,
+		},
+		"rotation_grace_period": {
+			Description:      `Time for which the previous token is kept enabled after a rotation, before it is deleted on the next apply`,
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "7d",
+			ValidateDiagFunc: validateRotationDuration,
+		},
+		"discard_access_token": {
+			Description: "Keep access_token out of the Terraform state, looking the token up by name whenever it is needed",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+
+		// Computed fields
+		"access_token": {
+			Description: "Access token for Rollbar API",
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+		},
+		"previous_access_token": {
+			Description: "Access token replaced by the last rotation, until it is deleted",
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+		},
+		"rotated_at": {
+			Description: "Time the token was created or last rotated, in seconds since the epoch",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"previous_token_delete_after": {
+			Description: "Time after which the previous token is deleted on the next apply, in seconds since the epoch",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"date_created": {
+			Description: "Date the project was created",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"date_modified": {
+			Description: "Date the project was last modified",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"cur_rate_limit_window_count": {
+			Description: "Count of calls in the current window",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"cur_rate_limit_window_start": {
+			Description: "Time when the current window began",
+			Type:        schema.TypeInt,
+			Computed:    true,
 		},
 	}
 }
 
-func resourceProjectAccessTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	projectID := d.Get("project_id").(int)
-	name := d.Get("name").(string)
+// projectAccessTokenCreateArgs returns the arguments to create the project
+// access token configured in d.
+func projectAccessTokenCreateArgs(d *schema.ResourceData) client.ProjectAccessTokenCreateArgs {
 	scopesInterface := d.Get("scopes").(*schema.Set)
 	scopes := []client.Scope{}
 	for _, v := range scopesInterface.List() {
 		s := v.(string)
 		scopes = append(scopes, client.Scope(s))
 	}
-	status := client.Status(d.Get("status").(string))
-	size := d.Get("rate_limit_window_size").(int)
-	count := d.Get("rate_limit_window_count").(int)
+	return client.ProjectAccessTokenCreateArgs{
+		Name:                 d.Get("name").(string),
+		ProjectID:            d.Get("project_id").(int),
+		Scopes:               scopes,
+		Status:               client.Status(d.Get("status").(string)),
+		RateLimitWindowSize:  d.Get("rate_limit_window_size").(int),
+		RateLimitWindowCount: d.Get("rate_limit_window_count").(int),
+	}
+}
+
+func resourceProjectAccessTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	args := projectAccessTokenCreateArgs(d)
 	l := log.With().
-		Int("project_id", projectID).
-		Str("name", name).
-		Int("rate_limit_window_size", size).
-		Int("rate_limit_window_count", count).
-		Interface("scopes", scopes).
-		Interface("status", status).
+		Int("project_id", args.ProjectID).
+		Str("name", args.Name).
+		Int("rate_limit_window_size", args.RateLimitWindowSize).
+		Int("rate_limit_window_count", args.RateLimitWindowCount).
+		Interface("scopes", args.Scopes).
+		Interface("status", args.Status).
 		Logger()
 	l.Debug().Msg("Creating new project access token")
 
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarProjectAccessToken)
-	pat, err := c.CreateProjectAccessToken(client.ProjectAccessTokenCreateArgs{
-		Name:                 name,
-		ProjectID:            projectID,
-		Scopes:               scopes,
-		Status:               status,
-		RateLimitWindowSize:  size,
-		RateLimitWindowCount: count,
-	})
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarProjectAccessToken)
+	pat, err := c.ProjectAccessTokens.CreateProjectAccessToken(ctx, args)
 
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	d.SetId(getMD5Hash(pat.AccessToken))
+	d.SetId(projectAccessTokenID(args.ProjectID, pat.Name))
 	mustSet(d, "access_token", pat.AccessToken)
-	return resourceProjectAccessTokenRead(ctx, d, m)
+	mustSet(d, "rotated_at", timeNow().Unix())
+	return resourceProjectAccessTokenRead(client.WithNotFoundRetry(ctx), d, m)
 }
 
 func resourceProjectAccessTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -179,22 +267,32 @@ func resourceProjectAccessTokenRead(ctx context.Context, d *schema.ResourceData,
 		Logger()
 	l.Debug().Msg("Reading resource project access token")
 
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarProjectAccessToken)
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarProjectAccessToken)
 
-	pat, err := c.ReadProjectAccessToken(projectID, accessToken)
+	var pat client.ProjectAccessToken
+	var err error
+	if accessToken == "" {
+		// Imported by name
+		pat, err = findProjectAccessTokenByName(ctx, c.ProjectAccessTokens, projectID, d.Get("name").(string))
+	} else {
+		pat, err = c.ProjectAccessTokens.ReadProjectAccessToken(ctx, projectID, accessToken)
+	}
 
-	if err == client.ErrNotFound {
+	if errors.Is(err, client.ErrNotFound) {
 		d.SetId("")
 		l.Debug().Msg("Token not found on Rollbar - removed from state")
 		return nil
 	}
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	var mPat map[string]interface{}
 	mustDecodeMapStructure(pat, &mPat)
+	if d.Get("discard_access_token").(bool) {
+		mPat["access_token"] = ""
+	}
 	for k, v := range mPat {
 		mustSet(d, k, v)
 	}
@@ -202,8 +300,64 @@ func resourceProjectAccessTokenRead(ctx context.Context, d *schema.ResourceData,
 	return diags
 }
 
+// findProjectAccessTokenByName returns the access token with the given name
+// in a project.  Of several tokens with the name, such as a token and a
+// disabled token of the same name, only an enabled one is chosen; more than
+// one enabled token is an error.
+func findProjectAccessTokenByName(ctx context.Context, api client.ProjectAccessTokensAPI, projectID int, name string) (client.ProjectAccessToken, error) {
+	pats, err := api.ListProjectAccessTokens(ctx, projectID)
+	if err != nil {
+		return client.ProjectAccessToken{}, err
+	}
+	var named, enabled []client.ProjectAccessToken
+	for _, pat := range pats {
+		if pat.Name != name {
+			continue
+		}
+		named = append(named, pat)
+		if pat.Status == client.StatusEnabled {
+			enabled = append(enabled, pat)
+		}
+	}
+	switch {
+	case len(named) == 1:
+		return named[0], nil
+	case len(enabled) == 1:
+		return enabled[0], nil
+	case len(named) == 0:
+		return client.ProjectAccessToken{}, client.ErrNotFound
+	}
+	return client.ProjectAccessToken{}, fmt.Errorf("project %d has %d access tokens named %q; import by PROJECT-ID/ACCESS-TOKEN instead", projectID, len(named), name)
+}
+
+// projectAccessTokenValue returns the access token in the state, or looks it
+// up by its name in the state if it was discarded.
+func projectAccessTokenValue(ctx context.Context, d *schema.ResourceData, api client.ProjectAccessTokensAPI) (string, error) {
+	accessToken, _ := d.GetChange("access_token")
+	if accessToken.(string) != "" {
+		return accessToken.(string), nil
+	}
+	name, _ := d.GetChange("name")
+	pat, err := findProjectAccessTokenByName(ctx, api, d.Get("project_id").(int), name.(string))
+	return pat.AccessToken, err
+}
+
 func resourceProjectAccessTokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	accessToken := d.Get("access_token").(string)
+	// Keeping or discarding the token also changes access_token
+	if d.HasChange("access_token") && !d.HasChange("discard_access_token") {
+		return rotateProjectAccessToken(ctx, d, m)
+	}
+	if d.HasChange("previous_access_token") {
+		if diags := deletePreviousProjectAccessToken(ctx, d, m); diags.HasError() {
+			return diags
+		}
+	}
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarProjectAccessToken)
+	accessToken, err := projectAccessTokenValue(ctx, d, c.ProjectAccessTokens)
+	if err != nil {
+		return diagFromErr(err)
+	}
 	projectID := d.Get("project_id").(int)
 	size := d.Get("rate_limit_window_size").(int)
 	count := d.Get("rate_limit_window_count").(int)
@@ -213,22 +367,39 @@ func resourceProjectAccessTokenUpdate(ctx context.Context, d *schema.ResourceDat
 		RateLimitWindowSize:  size,
 		RateLimitWindowCount: count,
 	}
+	if d.HasChange("name") {
+		args.Name = d.Get("name").(string)
+	}
+	if d.HasChange("scopes") {
+		for _, s := range d.Get("scopes").(*schema.Set).List() {
+			args.Scopes = append(args.Scopes, client.Scope(s.(string)))
+		}
+	}
+	if d.HasChange("status") {
+		args.Status = client.Status(d.Get("status").(string))
+	}
 	l := log.With().Interface("args", args).Logger()
 	l.Debug().Msg("Updating resource project access token")
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarProjectAccessToken)
 
-	err := c.UpdateProjectAccessToken(args)
+	err = c.ProjectAccessTokens.UpdateProjectAccessToken(ctx, args)
 	if err != nil {
 		log.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	diags := resourceProjectAccessTokenRead(ctx, d, m)
 	return diags
 }
 
 func resourceProjectAccessTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	accessToken := d.Get("access_token").(string)
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarProjectAccessToken)
+	accessToken, err := projectAccessTokenValue(ctx, d, c.ProjectAccessTokens)
+	if errors.Is(err, client.ErrNotFound) {
+		return deletePreviousProjectAccessToken(ctx, d, m)
+	}
+	if err != nil {
+		return diagFromErr(err)
+	}
 	projectID := d.Get("project_id").(int)
 
 	l := log.With().
@@ -237,36 +408,50 @@ func resourceProjectAccessTokenDelete(ctx context.Context, d *schema.ResourceDat
 		Logger()
 	l.Debug().Msg("Deleting resource project access token")
 
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarProjectAccessToken)
-	err := c.DeleteProjectAccessToken(projectID, accessToken)
+	err = c.ProjectAccessTokens.DeleteProjectAccessToken(ctx, projectID, accessToken)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	return nil
+	return deletePreviousProjectAccessToken(ctx, d, m)
 }
 
-func resourceProjectAccessTokenImporter(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+// resourceProjectAccessTokenImporter imports a project access token by an ID
+// of the form PROJECT-ID,NAME, or PROJECT-ID/ACCESS-TOKEN.
+func resourceProjectAccessTokenImporter(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	l := log.With().Str("id", d.Id()).Logger()
 	l.Debug().Msg("Importing resource rollbar project access token")
-	idParts := strings.Split(d.Id(), "/")
+	sep := ComplexImportSeparator
+	if !strings.Contains(d.Id(), sep) {
+		sep = "/"
+	}
+	idParts := strings.SplitN(d.Id(), sep, 2)
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
-		return nil, fmt.Errorf("unexpected format of ID (%q), expected PROJECT-ID/ACCESS-TOKEN", d.Id())
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected PROJECT-ID,NAME or PROJECT-ID/ACCESS-TOKEN", d.Id())
 	}
-	projectIDString := idParts[0]
-	accessToken := idParts[1]
-	projectID, err := strconv.Atoi(projectIDString)
+	projectID, err := strconv.Atoi(idParts[0])
 	if err != nil {
 		log.Err(err).Send()
 		return nil, err
 	}
 	l.Debug().
 		Int("project_id", projectID).
-		Str("access_token", accessToken).
 		Send()
 	mustSet(d, "project_id", projectID)
-	mustSet(d, "access_token", accessToken)
-	d.SetId(getMD5Hash(accessToken))
+	if sep == "/" {
+		// Keep the access token out of the ID
+		c := meta.(*Meta)
+		ctx = client.WithResource(ctx, rollbarProjectAccessToken)
+		pat, err := c.ProjectAccessTokens.ReadProjectAccessToken(ctx, projectID, idParts[1])
+		if err != nil {
+			return nil, err
+		}
+		mustSet(d, "access_token", pat.AccessToken)
+		d.SetId(projectAccessTokenID(projectID, pat.Name))
+	} else {
+		mustSet(d, "name", idParts[1])
+	}
+	// Imports do not set defaults
+	mustSet(d, "rotation_grace_period", projectAccessTokenSchema()["rotation_grace_period"].Default)
 	return []*schema.ResourceData{d}, nil
 }
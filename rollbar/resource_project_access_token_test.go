@@ -0,0 +1,375 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rollbar/terraform-provider-rollbar/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResourceProjectAccessTokenUpdate checks that the name, scopes and
+// status of a project access token are changed in place, keeping its secret.
+func TestResourceProjectAccessTokenUpdate(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	meta := NewMeta(fake, fake)
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+
+	r := resourceProjectAccessToken()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_id": p.ID,
+		"name":       "ci",
+		"scopes":     []interface{}{"post_server_item"},
+	})
+	diags := resourceProjectAccessTokenCreate(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	token := d.Get("access_token").(string)
+	assert.Equal(t, fmt.Sprintf("%d,ci", p.ID), d.Id())
+
+	state := d.State()
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": p.ID,
+		"name":       "ci-disabled",
+		"scopes":     []interface{}{"post_server_item", "read"},
+		"status":     "disabled",
+	})
+	diff, err := r.Diff(ctx, state, cfg, meta)
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+	state, diags = r.Apply(ctx, state, diff, meta)
+	require.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, fmt.Sprintf("%d,ci", p.ID), state.ID, "renaming keeps the ID")
+	pat, err := fake.ReadProjectAccessToken(ctx, p.ID, token)
+	require.NoError(t, err)
+	assert.Equal(t, "ci-disabled", pat.Name)
+	assert.ElementsMatch(t, []client.Scope{client.ScopePostServerItem, client.ScopeRead}, pat.Scopes)
+	assert.Equal(t, client.StatusDisabled, pat.Status)
+}
+
+// TestResourceProjectAccessTokenRotate checks that changing keepers, or the
+// token reaching rotate_after, replaces the token with a new one, and that the
+// disabled previous token is deleted once its grace period has passed.
+func TestResourceProjectAccessTokenRotate(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	meta := NewMeta(fake, fake)
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	now := time.Now()
+	defer func() { timeNow = time.Now }()
+	timeNow = func() time.Time { return now }
+
+	r := resourceProjectAccessToken()
+	raw := map[string]interface{}{
+		"project_id":            p.ID,
+		"name":                  "ci",
+		"scopes":                []interface{}{"post_server_item"},
+		"keepers":               map[string]interface{}{"quarter": "q1"},
+		"rotate_after":          "90d",
+		"rotation_grace_period": "1d",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	diags := resourceProjectAccessTokenCreate(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	state := d.State()
+	first := state.Attributes["access_token"]
+
+	// apply plans and applies raw, returning whether there was a diff.
+	apply := func() bool {
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), meta)
+		require.NoError(t, err)
+		if diff.Empty() {
+			return false
+		}
+		assert.False(t, diff.RequiresNew())
+		state, diags = r.Apply(ctx, state, diff, meta)
+		require.False(t, diags.HasError(), diags)
+		return true
+	}
+	assert.False(t, apply())
+
+	raw["keepers"] = map[string]interface{}{"quarter": "q2"}
+	require.True(t, apply())
+	second := state.Attributes["access_token"]
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, first, state.Attributes["previous_access_token"])
+	pat, err := fake.ReadProjectAccessToken(ctx, p.ID, first)
+	require.NoError(t, err)
+	assert.Equal(t, client.StatusEnabled, pat.Status, "the old token stays enabled for the grace period")
+	pat, err = fake.ReadProjectAccessToken(ctx, p.ID, second)
+	require.NoError(t, err)
+	assert.Equal(t, client.StatusEnabled, pat.Status)
+	assert.False(t, apply())
+
+	// Grace period over
+	now = now.Add(25 * time.Hour)
+	require.True(t, apply())
+	assert.Equal(t, second, state.Attributes["access_token"])
+	assert.Empty(t, state.Attributes["previous_access_token"])
+	_, err = fake.ReadProjectAccessToken(ctx, p.ID, first)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+
+	// Rotation due
+	now = now.Add(90 * 24 * time.Hour)
+	require.True(t, apply())
+	assert.NotEqual(t, second, state.Attributes["access_token"])
+	assert.Equal(t, second, state.Attributes["previous_access_token"])
+	assert.False(t, apply())
+}
+
+// failingTokensFake is a Fake that fails to create access tokens if noCreate
+// is set, and to delete the access tokens in noDelete.
+type failingTokensFake struct {
+	*clienttest.Fake
+	noCreate *bool
+	noDelete map[string]bool
+}
+
+func (f failingTokensFake) CreateProjectAccessToken(ctx context.Context, args client.ProjectAccessTokenCreateArgs) (client.ProjectAccessToken, error) {
+	if *f.noCreate {
+		return client.ProjectAccessToken{}, &client.APIError{StatusCode: http.StatusInternalServerError}
+	}
+	return f.Fake.CreateProjectAccessToken(ctx, args)
+}
+
+func (f failingTokensFake) DeleteProjectAccessToken(ctx context.Context, projectID int, token string) error {
+	if f.noDelete[token] {
+		return &client.APIError{StatusCode: http.StatusInternalServerError}
+	}
+	return f.Fake.DeleteProjectAccessToken(ctx, projectID, token)
+}
+
+// TestResourceProjectAccessTokenRotateFailure checks that a rotation that
+// cannot create the new token keeps the old one and is retried, and that one
+// that cannot delete the older previous token still completes, with a warning.
+func TestResourceProjectAccessTokenRotateFailure(t *testing.T) {
+	ctx := context.Background()
+	noCreate := false
+	fake := failingTokensFake{Fake: clienttest.NewFake(), noCreate: &noCreate, noDelete: map[string]bool{}}
+	meta := NewMeta(fake, fake)
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+
+	r := resourceProjectAccessToken()
+	raw := map[string]interface{}{
+		"project_id": p.ID,
+		"name":       "ci",
+		"scopes":     []interface{}{"post_server_item"},
+		"keepers":    map[string]interface{}{"quarter": "q1"},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	diags := resourceProjectAccessTokenCreate(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	state := d.State()
+	first := state.Attributes["access_token"]
+
+	// rotate plans and applies a change of keepers.
+	rotate := func(quarter string) diag.Diagnostics {
+		raw["keepers"] = map[string]interface{}{"quarter": quarter}
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), meta)
+		require.NoError(t, err)
+		var diags diag.Diagnostics
+		state, diags = r.Apply(ctx, state, diff, meta)
+		return diags
+	}
+
+	noCreate = true
+	diags = rotate("q2")
+	require.True(t, diags.HasError())
+	assert.Equal(t, first, state.Attributes["access_token"])
+	assert.Equal(t, "q1", state.Attributes["keepers.quarter"], "the rotation is retried")
+	noCreate = false
+
+	diags = rotate("q2")
+	require.False(t, diags.HasError(), diags)
+	second := state.Attributes["access_token"]
+	require.NotEqual(t, first, second)
+	assert.Equal(t, first, state.Attributes["previous_access_token"])
+
+	fake.noDelete[first] = true
+	diags = rotate("q3")
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"warning: Unable to delete older project access token"}, summaries(diags))
+	assert.Equal(t, second, state.Attributes["previous_access_token"])
+	pat, err := fake.ReadProjectAccessToken(ctx, p.ID, second)
+	require.NoError(t, err)
+	assert.Equal(t, client.StatusEnabled, pat.Status)
+}
+
+// TestResourceProjectAccessTokenDiscard checks that a token with
+// discard_access_token is kept out of state but can still be updated and
+// deleted, and that the option can be turned off and on.
+func TestResourceProjectAccessTokenDiscard(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	meta := NewMeta(fake, fake)
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+
+	r := resourceProjectAccessToken()
+	raw := map[string]interface{}{
+		"project_id":           p.ID,
+		"name":                 "ci",
+		"scopes":               []interface{}{"post_server_item"},
+		"discard_access_token": true,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	diags := resourceProjectAccessTokenCreate(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	state := d.State()
+	assert.Empty(t, state.Attributes["access_token"])
+	pat, err := findProjectAccessTokenByName(ctx, fake, p.ID, "ci")
+	require.NoError(t, err)
+	token := pat.AccessToken
+
+	// apply plans and applies raw.
+	apply := func() {
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), meta)
+		require.NoError(t, err)
+		state, diags = r.Apply(ctx, state, diff, meta)
+		require.False(t, diags.HasError(), diags)
+	}
+
+	raw["name"] = "ci-read"
+	raw["scopes"] = []interface{}{"post_server_item", "read"}
+	apply()
+	assert.Empty(t, state.Attributes["access_token"])
+	pat, err = fake.ReadProjectAccessToken(ctx, p.ID, token)
+	require.NoError(t, err)
+	assert.Equal(t, "ci-read", pat.Name)
+	assert.ElementsMatch(t, []client.Scope{client.ScopePostServerItem, client.ScopeRead}, pat.Scopes)
+
+	raw["discard_access_token"] = false
+	apply()
+	assert.Equal(t, token, state.Attributes["access_token"])
+
+	raw["discard_access_token"] = true
+	apply()
+	assert.Empty(t, state.Attributes["access_token"])
+
+	raw["keepers"] = map[string]interface{}{"quarter": "q1"}
+	_, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), meta)
+	assert.ErrorContains(t, err, "discard_access_token")
+	delete(raw, "keepers")
+
+	diags = resourceProjectAccessTokenDelete(ctx, r.Data(state), meta)
+	require.False(t, diags.HasError(), diags)
+	_, err = fake.ReadProjectAccessToken(ctx, p.ID, token)
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+// TestUpgradeProjectAccessTokenStateV0 checks that the MD5 hash ID of version
+// 0 of the state is replaced with the project ID and token name.
+func TestUpgradeProjectAccessTokenStateV0(t *testing.T) {
+	state, err := upgradeProjectAccessTokenStateV0(context.Background(), map[string]interface{}{
+		"id":           "9c1ea8d4f6b0bd9c2f6a0bd8cd47a1a0",
+		"project_id":   float64(411703),
+		"name":         "ci",
+		"access_token": "d19f7ada16534b1c94e91d9da3dbae5a",
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "411703,ci", state["id"])
+	assert.Equal(t, "d19f7ada16534b1c94e91d9da3dbae5a", state["access_token"])
+
+	state, err = upgradeProjectAccessTokenStateV0(context.Background(), map[string]interface{}{
+		"project_id": json.Number("411703"),
+		"name":       "ci",
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "411703,ci", state["id"])
+
+	_, err = upgradeProjectAccessTokenStateV0(context.Background(), map[string]interface{}{"id": "x"}, nil)
+	assert.Error(t, err)
+}
+
+// TestResourceProjectAccessTokenImport checks importing a token by project ID
+// and name, and by project ID and access token.
+func TestResourceProjectAccessTokenImport(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	meta := NewMeta(fake, fake)
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	pat, err := fake.CreateProjectAccessToken(ctx, client.ProjectAccessTokenCreateArgs{
+		ProjectID: p.ID,
+		Name:      "ci",
+		Scopes:    []client.Scope{client.ScopeRead},
+	})
+	require.NoError(t, err)
+	id := fmt.Sprintf("%d,ci", p.ID)
+
+	// importAndRead imports the token with importID and reads it.
+	importAndRead := func(importID string) (*schema.ResourceData, diag.Diagnostics) {
+		d := resourceProjectAccessToken().Data(nil)
+		d.SetId(importID)
+		ds, err := resourceProjectAccessTokenImporter(ctx, d, meta)
+		require.NoError(t, err)
+		require.Len(t, ds, 1)
+		return ds[0], resourceProjectAccessTokenRead(ctx, ds[0], meta)
+	}
+	for _, importID := range []string{id, fmt.Sprintf("%d/%s", p.ID, pat.AccessToken)} {
+		d, diags := importAndRead(importID)
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, id, d.Id(), importID)
+		assert.Equal(t, pat.AccessToken, d.Get("access_token"), importID)
+		assert.Equal(t, "ci", d.Get("name"), importID)
+	}
+
+	// A disabled token of the same name, as left by a rotation, is ignored
+	_, err = fake.CreateProjectAccessToken(ctx, client.ProjectAccessTokenCreateArgs{
+		ProjectID: p.ID,
+		Name:      "ci",
+		Scopes:    []client.Scope{client.ScopeRead},
+		Status:    client.StatusDisabled,
+	})
+	require.NoError(t, err)
+	d, diags := importAndRead(id)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, pat.AccessToken, d.Get("access_token"))
+
+	// Two enabled tokens of the same name are ambiguous
+	_, err = fake.CreateProjectAccessToken(ctx, client.ProjectAccessTokenCreateArgs{
+		ProjectID: p.ID,
+		Name:      "ci",
+		Scopes:    []client.Scope{client.ScopeRead},
+	})
+	require.NoError(t, err)
+	_, diags = importAndRead(id)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `has 3 access tokens named "ci"`)
+}
@@ -53,6 +53,14 @@ func resourceServiceLink() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+
+			// Optional
+			schemaKeyProjectID: {
+				Description: "ID of the project the service link belongs to, whose access token is set in provider argument project_api_keys.  Defaults to the project of provider argument project_api_key.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+			},
 		},
 	}
 }
@@ -66,15 +74,18 @@ func resourceServiceLinkCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	l.Info().Msg("Creating rollbar_service_link resource")
 
-	c := m.(map[string]*client.RollbarAPIClient)[projectKeyToken]
-	c.SetHeaderResource(rollbarServiceLink)
+	c, diags := projectMeta(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	ctx = client.WithResource(ctx, rollbarServiceLink)
 
-	sl, err := c.CreateServiceLink(name, template)
+	sl, err := c.ServiceLinks.CreateServiceLink(ctx, name, template)
 
 	if err != nil {
 		l.Err(err).Send()
 		d.SetId("") // removing from the state
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	l = l.With().Int("id", sl.ID).Logger()
 
@@ -94,20 +105,23 @@ func resourceServiceLinkUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	l.Info().Msg("Creating rollbar_service_link resource")
 
-	c := m.(map[string]*client.RollbarAPIClient)[projectKeyToken]
-	c.SetHeaderResource(rollbarServiceLink)
-	sl, err := c.UpdateServiceLink(id, name, template)
+	c, diags := projectMeta(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	ctx = client.WithResource(ctx, rollbarServiceLink)
+	sl, err := c.ServiceLinks.UpdateServiceLink(ctx, id, name, template)
 
 	if err != nil {
 		l.Err(err).Send()
 		d.SetId("") // removing from the state
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	if sl.ID != id {
 		err = errors.New("IDs are not equal")
 		l.Err(err).Send()
 		d.SetId("") // removing from the state
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	l = l.With().Int("id", sl.ID).Logger()
 
@@ -121,19 +135,22 @@ func resourceServiceLinkRead(ctx context.Context, d *schema.ResourceData, m inte
 		Int("id", id).
 		Logger()
 	l.Info().Msg("Reading rollbar_service_link resource")
-	c := m.(map[string]*client.RollbarAPIClient)[projectKeyToken]
-	c.SetHeaderResource(rollbarServiceLink)
+	c, diags := projectMeta(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	ctx = client.WithResource(ctx, rollbarServiceLink)
 
-	sl, err := c.ReadServiceLink(id)
+	sl, err := c.ServiceLinks.ReadServiceLink(ctx, id)
 
-	if err == client.ErrNotFound {
+	if errors.Is(err, client.ErrNotFound) {
 		d.SetId("")
 		l.Info().Msg("Service Link not found - removed from state")
 		return nil
 	}
 	if err != nil {
 		l.Err(err).Msg("error reading rollbar_service_link resource")
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	mustSet(d, "name", sl.Name)
@@ -146,13 +163,16 @@ func resourceServiceLinkDelete(ctx context.Context, d *schema.ResourceData, m in
 	id := mustGetID(d)
 	l := log.With().Int("id", id).Logger()
 	l.Info().Msg("Deleting rollbar_service_link resource")
-	c := m.(map[string]*client.RollbarAPIClient)[projectKeyToken]
-	c.SetHeaderResource(rollbarServiceLink)
-	err := c.DeleteServiceLink(id)
+	c, diags := projectMeta(ctx, d, m)
+	if diags != nil {
+		return diags
+	}
+	ctx = client.WithResource(ctx, rollbarServiceLink)
+	err := c.ServiceLinks.DeleteServiceLink(ctx, id)
 
 	if err != nil {
 		l.Err(err).Msg("Error deleting rollbar_service_link resource")
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	l.Debug().Msg("Successfully deleted rollbar_service_link resource")
 	return nil
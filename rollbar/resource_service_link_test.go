@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rollbar/terraform-provider-rollbar/client/clienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResourceServiceLinkProjectID checks that a service link with project_id
+// set is managed with the access token of that project, and that a project
+// without a token in project_api_keys is refused.
+func TestResourceServiceLinkProjectID(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	other := clienttest.NewFake()
+	meta := NewMeta(fake, fake)
+	meta.SetProjectAPI(42, other)
+
+	d := schema.TestResourceDataRaw(t, resourceServiceLink().Schema, map[string]interface{}{
+		"name":             "commit",
+		"template":         "https://example.com/{{code_version}}",
+		schemaKeyProjectID: 42,
+	})
+	diags := resourceServiceLinkCreate(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+
+	links, _ := other.ListSerivceLinks(ctx)
+	assert.Len(t, links, 1)
+	links, _ = fake.ListSerivceLinks(ctx)
+	assert.Empty(t, links)
+
+	diags = resourceServiceLinkRead(ctx, d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "commit", d.Get("name"))
+
+	d = schema.TestResourceDataRaw(t, resourceServiceLink().Schema, map[string]interface{}{
+		"name":             "commit",
+		"template":         "https://example.com/{{code_version}}",
+		schemaKeyProjectID: 43,
+	})
+	diags = resourceServiceLinkCreate(ctx, d, meta)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "no access token for project 43 in provider argument project_api_keys")
+}
+
+// TestResourceServiceLinkDerivedToken checks that with derived project tokens
+// a service link in a project without a configured token is managed with a
+// token found with the account access token, once per project.
+func TestResourceServiceLinkDerivedToken(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	meta := NewMeta(fake, fake)
+	var tokens []string
+	meta.DeriveProjectTokens(func(token string) client.RollbarAPI {
+		tokens = append(tokens, token)
+		return fake
+	}, []client.Scope{client.ScopeWrite})
+
+	for i := 0; i < 2; i++ {
+		d := schema.TestResourceDataRaw(t, resourceServiceLink().Schema, map[string]interface{}{
+			"name":             fmt.Sprintf("commit-%d", i),
+			"template":         "https://example.com/{{code_version}}",
+			schemaKeyProjectID: p.ID,
+		})
+		diags := resourceServiceLinkCreate(ctx, d, meta)
+		require.False(t, diags.HasError(), diags)
+	}
+	require.Len(t, tokens, 1)
+	pat, err := fake.ReadProjectAccessToken(ctx, p.ID, tokens[0])
+	require.NoError(t, err)
+	assert.Equal(t, "write", pat.Name)
+}
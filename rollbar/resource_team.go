@@ -24,6 +24,7 @@ package rollbar
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -96,19 +97,19 @@ func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, m interface
 	level := d.Get("access_level").(string)
 	l := log.With().Str("name", name).Str("access_level", level).Logger()
 	l.Info().Msg("Creating rollbar_team resource")
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarTeam)
-	t, err := c.CreateTeam(name, level)
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarTeam)
+	t, err := c.Teams.CreateTeam(ctx, name, level)
 
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	teamID := t.ID
 	l = l.With().Int("teamID", teamID).Logger()
 	d.SetId(strconv.Itoa(teamID))
 	l.Debug().Int("id", teamID).Msg("Successfully created rollbar_team resource")
-	return resourceTeamRead(ctx, d, m)
+	return resourceTeamRead(client.WithNotFoundRetry(ctx), d, m)
 }
 
 func resourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -117,18 +118,18 @@ func resourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		Int("id", id).
 		Logger()
 	l.Info().Msg("Reading rollbar_team resource")
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarTeam)
-	t, err := c.ReadTeam(id)
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarTeam)
+	t, err := c.Teams.ReadTeam(ctx, id)
 
-	if err == client.ErrNotFound {
+	if errors.Is(err, client.ErrNotFound) {
 		d.SetId("")
 		l.Err(err).Msg("Team not found - removed from state")
 		return nil
 	}
 	if err != nil {
 		l.Err(err).Msg("error reading rollbar_team resource")
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	mustSet(d, "name", t.Name)
 	mustSet(d, "account_id", t.AccountID)
@@ -142,13 +143,13 @@ func resourceTeamDelete(ctx context.Context, d *schema.ResourceData, m interface
 
 	l := log.With().Int("id", id).Logger()
 	l.Info().Msg("Deleting rollbar_team resource")
-	c := m.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarTeam)
-	err := c.DeleteTeam(id)
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarTeam)
+	err := c.Teams.DeleteTeam(ctx, id)
 
 	if err != nil {
 		l.Err(err).Msg("Error deleting rollbar_team resource")
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	l.Debug().Msg("Successfully deleted rollbar_team resource")
 	return nil
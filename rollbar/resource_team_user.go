@@ -24,6 +24,7 @@ package rollbar
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -103,7 +104,7 @@ func teamUserFromID(id string) (teamID int, s string, err error) {
 }
 
 func resourceTeamUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
+	c := meta.(*Meta)
 	teamID := d.Get("team_id").(int)
 	email := d.Get("email").(string)
 	l := log.With().
@@ -113,29 +114,29 @@ func resourceTeamUserCreate(ctx context.Context, d *schema.ResourceData, meta in
 	l.Info().Msg("Creating rollbar_team_user resource")
 
 	// Check if a Rollbar user exists for this email
-	c.SetHeaderResource(rollbarTeamUser)
-	userID, err := c.FindUserID(email)
+	ctx = client.WithResource(ctx, rollbarTeamUser)
+	userID, err := c.Users.FindUserID(ctx, email)
 
 	l = l.With().Int("user_id", userID).Logger()
-	switch err {
-	case nil: // User Found, assign them to the team
+	switch {
+	case err == nil: // User Found, assign them to the team
 		l.Debug().Msg("Found existing user")
 		mustSet(d, "user_id", userID)
 		mustSet(d, "status", "registered")
-		er := c.AssignUserToTeam(teamID, userID)
+		er := c.Teams.AssignUserToTeam(ctx, teamID, userID)
 		if er != nil {
 			l.Err(er).Msg("error assigning user to team")
-			return diag.FromErr(er)
+			return diagFromErr(er)
 		}
 		mustSet(d, "invite_id", 0)
 		l.Debug().Msg("Assigned user to team")
-	case client.ErrNotFound: // User not found, send an invitation
+	case errors.Is(err, client.ErrNotFound): // User not found, send an invitation
 		l.Debug().Msg("Existing user not found")
 		mustSet(d, "status", "invited")
-		inv, er := c.CreateInvitation(teamID, email)
+		inv, er := c.Invitations.CreateInvitation(ctx, teamID, email)
 		if er != nil {
 			l.Err(er).Msg("error assigning user to team")
-			return diag.FromErr(er)
+			return diagFromErr(er)
 		}
 		l.Debug().
 			Int("inviteID", inv.ID).
@@ -143,18 +144,18 @@ func resourceTeamUserCreate(ctx context.Context, d *schema.ResourceData, meta in
 		mustSet(d, "invite_id", inv.ID)
 	default: // Actual error
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(teamUserID(teamID, email))
 	l.Debug().Msg("Successfully created or updated rollbar_team_user resource")
-	return resourceTeamUserRead(ctx, d, meta)
+	return resourceTeamUserRead(client.WithNotFoundRetry(ctx), d, meta)
 }
 
-func resourceTeamUserRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceTeamUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	teamID, email, err := teamUserFromID(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	userID := d.Get("user_id").(int)
 	l := log.With().
@@ -163,14 +164,14 @@ func resourceTeamUserRead(_ context.Context, d *schema.ResourceData, meta interf
 		Int("team_id", teamID).
 		Logger()
 	l.Info().Msg("Reading rollbar_team_user resource")
-	c := meta.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarTeamUser)
+	c := meta.(*Meta)
+	ctx = client.WithResource(ctx, rollbarTeamUser)
 
 	// If user ID is not in state, try to query it from Rollbar
 	if userID == 0 {
-		userID, err = c.FindUserID(email)
-		switch err {
-		case nil:
+		userID, err = c.Users.FindUserID(ctx, email)
+		switch {
+		case err == nil:
 			l = log.With().
 				Str("email", email).
 				Int("userID", userID).
@@ -178,21 +179,21 @@ func resourceTeamUserRead(_ context.Context, d *schema.ResourceData, meta interf
 			l.Debug().Msg("Found registered user")
 			mustSet(d, "user_id", userID)
 			mustSet(d, "status", "registered")
-		case client.ErrNotFound:
+		case errors.Is(err, client.ErrNotFound):
 			l.Debug().Msg("No registered user found")
 			mustSet(d, "status", "invited")
 		default:
 			l.Err(err).Send()
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
 	if userID != 0 {
 		// Check if user is assigned to the team
-		assigned, err := c.IsUserAssignedToTeam(teamID, userID)
+		assigned, err := c.Teams.IsUserAssignedToTeam(ctx, teamID, userID)
 		if err != nil {
 			l.Err(err).Msg("Error checking if user is assigned to team.")
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		if assigned {
 			mustSet(d, "team_id", teamID)
@@ -202,10 +203,10 @@ func resourceTeamUserRead(_ context.Context, d *schema.ResourceData, meta interf
 		_ = d.Set("invite_id", nil)
 	} else {
 		// Check if user is invited to the team
-		invitations, err := c.ListPendingInvitations(teamID)
+		invitations, err := c.Invitations.ListPendingInvitations(ctx, teamID)
 		if err != nil {
 			l.Err(err).Msg("Error checking if user has pending invitation.")
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 		var invite client.Invitation
 		for _, i := range invitations {
@@ -223,7 +224,7 @@ func resourceTeamUserRead(_ context.Context, d *schema.ResourceData, meta interf
 	return nil
 }
 
-func resourceTeamUserDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceTeamUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	email := d.Id()
 	teamID := d.Get("team_id").(int)
 	l := log.With().
@@ -231,25 +232,25 @@ func resourceTeamUserDelete(_ context.Context, d *schema.ResourceData, meta inte
 		Int("team_id", teamID).
 		Logger()
 	l.Info().Msg("Deleting rollbar_team_user resource")
-	c := meta.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarTeamUser)
+	c := meta.(*Meta)
+	ctx = client.WithResource(ctx, rollbarTeamUser)
 
 	userID := d.Get("user_id").(int)
 	if userID == 0 {
 		// Cancel invitation
 		inviteID := d.Get("invite_id").(int)
-		err := c.CancelInvitation(inviteID)
-		if err != client.ErrNotFound {
+		err := c.Invitations.CancelInvitation(ctx, inviteID)
+		if !errors.Is(err, client.ErrNotFound) {
 			l.Err(err).Send()
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	} else {
 		// Remove user from team
-		err := c.RemoveUserFromTeam(userID, teamID)
+		err := c.Teams.RemoveUserFromTeam(ctx, userID, teamID)
 		if err != nil {
-			if err != client.ErrNotFound {
+			if !errors.Is(err, client.ErrNotFound) {
 				l.Err(err).Send()
-				return diag.FromErr(err)
+				return diagFromErr(err)
 			}
 		}
 	}
@@ -24,6 +24,7 @@ package rollbar
 
 import (
 	"context"
+	"errors"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -89,15 +90,18 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interf
 		Logger()
 	l.Info().Msg("Creating rollbar_user resource")
 	d.SetId(email)
-	return resourceUserCreateOrUpdate(ctx, d, meta)
+	if diags := resourceUserCreateOrUpdate(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+	return resourceUserRead(client.WithNotFoundRetry(ctx), d, meta)
 }
 
 // resourceUserCreateOrUpdate does the heavy lifting of assigning and/or
 // inviting user to specified groups, and removing user from groups no longer
-// specified.
+// specified.  The caller reads the user back.
 func resourceUserCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarUser)
+	c := meta.(*Meta)
+	ctx = client.WithResource(ctx, rollbarUser)
 	email := d.Get("email").(string)
 	teamIDs := getTeamIDs(d)
 	l := log.With().
@@ -107,19 +111,19 @@ func resourceUserCreateOrUpdate(ctx context.Context, d *schema.ResourceData, met
 	l.Debug().Msg("Creating or updating rollbar_user resource")
 
 	// Check if a Rollbar user exists for this email
-	userID, err := c.FindUserID(email)
+	userID, err := c.Users.FindUserID(ctx, email)
 	l = l.With().Int("user_id", userID).Logger()
-	switch err {
-	case nil:
+	switch {
+	case err == nil:
 		l.Debug().Msg("Found existing user")
 		mustSet(d, "user_id", userID)
 		mustSet(d, "status", "registered")
-	case client.ErrNotFound:
+	case errors.Is(err, client.ErrNotFound):
 		l.Debug().Msg("Existing user not found")
 		mustSet(d, "status", "invited")
 	default: // Actual error
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	// Teams to which this user SHOULD belong
@@ -128,13 +132,13 @@ func resourceUserCreateOrUpdate(ctx context.Context, d *schema.ResourceData, met
 		teamsExpected[id] = true
 	}
 
-	teamsCurrent, err := resourceUserCurrentTeams(c, email, userID, true)
+	teamsCurrent, err := resourceUserCurrentTeams(ctx, c, email, userID, true)
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
-	err = resourceUserAddTeams(resourceUserAddRemoveTeamsArgs{
-		client:        c,
+	err = resourceUserAddTeams(ctx, resourceUserAddRemoveTeamsArgs{
+		meta:          c,
 		userID:        userID,
 		email:         email,
 		teamsExpected: teamsExpected,
@@ -142,10 +146,10 @@ func resourceUserCreateOrUpdate(ctx context.Context, d *schema.ResourceData, met
 	})
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
-	err = resourceUserRemoveTeams(resourceUserAddRemoveTeamsArgs{
-		client:        c,
+	err = resourceUserRemoveTeams(ctx, resourceUserAddRemoveTeamsArgs{
+		meta:          c,
 		userID:        userID,
 		email:         email,
 		teamsExpected: teamsExpected,
@@ -153,18 +157,18 @@ func resourceUserCreateOrUpdate(ctx context.Context, d *schema.ResourceData, met
 	})
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId(email)
 	l.Debug().Msg("Successfully created or updated rollbar_user resource")
-	return resourceUserRead(ctx, d, meta)
+	return nil
 }
 
 // resourceUserAddRemoveTeamsArgs encapsulates the arguments to
 // resourceUserAddTeams and resourceUserRemoveTeams.
 type resourceUserAddRemoveTeamsArgs struct {
-	client        *client.RollbarAPIClient
+	meta          *Meta
 	userID        int
 	email         string
 	teamsExpected map[int]bool
@@ -174,7 +178,7 @@ type resourceUserAddRemoveTeamsArgs struct {
 // resourceUserAddTeams adds new team memberships to a Rollbar user, either by
 // assigning a registered user to the team or by inviting an email address to
 // the team.
-func resourceUserAddTeams(args resourceUserAddRemoveTeamsArgs) error {
+func resourceUserAddTeams(ctx context.Context, args resourceUserAddRemoveTeamsArgs) error {
 	l := log.With().
 		Int("user_id", args.userID).
 		Str("email", args.email).
@@ -198,14 +202,14 @@ func resourceUserAddTeams(args resourceUserAddRemoveTeamsArgs) error {
 		// If user already exists we can assign to teams without invitation.  If
 		// user does not already exist we must send an invitation.
 		if args.userID != 0 {
-			err := args.client.AssignUserToTeam(teamID, args.userID)
+			err := args.meta.Teams.AssignUserToTeam(ctx, teamID, args.userID)
 			if err != nil {
 				l.Err(err).Msg(errMsg)
 				return err
 			}
 			l.Debug().Msg("Assigned user to team")
 		} else {
-			inv, err := args.client.CreateInvitation(teamID, args.email)
+			inv, err := args.meta.Invitations.CreateInvitation(ctx, teamID, args.email)
 			if err != nil {
 				l.Err(err).Msg(errMsg)
 				return err
@@ -219,7 +223,7 @@ func resourceUserAddTeams(args resourceUserAddRemoveTeamsArgs) error {
 }
 
 // resourceUserRemoveTeams removes team memberships from a Rollbar user.
-func resourceUserRemoveTeams(args resourceUserAddRemoveTeamsArgs) error {
+func resourceUserRemoveTeams(ctx context.Context, args resourceUserAddRemoveTeamsArgs) error {
 	l := log.With().
 		Int("user_id", args.userID).
 		Str("email", args.email).
@@ -240,10 +244,10 @@ func resourceUserRemoveTeams(args resourceUserAddRemoveTeamsArgs) error {
 	// Leave teams
 	if args.userID != 0 {
 		l.Debug().Msg("Removing registered user from teams")
-		currentTeams, _ := args.client.ListUserTeams(args.userID)
+		currentTeams, _ := args.meta.Users.ListUserTeams(ctx, args.userID)
 		for _, t := range currentTeams {
 			if teamsToLeave[t.ID] {
-				err := args.client.RemoveUserFromTeam(args.userID, t.ID)
+				err := args.meta.Teams.RemoveUserFromTeam(ctx, args.userID, t.ID)
 				if err != nil {
 					l.Err(err).Msg(errMsg)
 					return err
@@ -254,14 +258,14 @@ func resourceUserRemoveTeams(args resourceUserAddRemoveTeamsArgs) error {
 
 	// Cancel invitations
 	l.Debug().Msg("Canceling invitations")
-	invitations, err := args.client.FindPendingInvitations(args.email)
-	if err != nil && err != client.ErrNotFound {
+	invitations, err := args.meta.Invitations.FindPendingInvitations(ctx, args.email)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
 		l.Err(err).Msg(errMsg)
 		return err
 	}
 	for _, inv := range invitations {
 		if teamsToLeave[inv.TeamID] {
-			err := args.client.CancelInvitation(inv.ID)
+			err := args.meta.Invitations.CancelInvitation(ctx, inv.ID)
 			if err != nil {
 				l.Err(err).Msg(errMsg)
 				return err
@@ -274,7 +278,7 @@ func resourceUserRemoveTeams(args resourceUserAddRemoveTeamsArgs) error {
 }
 
 // resourceUserCurrentTeams returns user's current team memberships.
-func resourceUserCurrentTeams(c *client.RollbarAPIClient, email string, userID int, filterSysTeams bool) (currentTeams map[int]bool, err error) {
+func resourceUserCurrentTeams(ctx context.Context, c *Meta, email string, userID int, filterSysTeams bool) (currentTeams map[int]bool, err error) {
 	l := log.With().
 		Str("email", email).
 		Int("user_id", userID).
@@ -285,11 +289,11 @@ func resourceUserCurrentTeams(c *client.RollbarAPIClient, email string, userID i
 	if userID != 0 {
 		var teams []client.Team
 		if filterSysTeams {
-			teams, err = c.ListUserCustomTeams(userID)
+			teams, err = c.Users.ListUserCustomTeams(ctx, userID)
 		} else {
-			teams, err = c.ListUserTeams(userID)
+			teams, err = c.Users.ListUserTeams(ctx, userID)
 		}
-		if err != nil && err != client.ErrNotFound {
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
 			l.Err(err).Send()
 			return
 		}
@@ -300,8 +304,8 @@ func resourceUserCurrentTeams(c *client.RollbarAPIClient, email string, userID i
 
 	// Teams to which email has been invited
 	var invitations []client.Invitation
-	invitations, err = c.FindPendingInvitations(email)
-	if err != nil && err != client.ErrNotFound {
+	invitations, err = c.Invitations.FindPendingInvitations(ctx, email)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
 		l.Err(err).Send()
 		return
 	}
@@ -315,7 +319,7 @@ func resourceUserCurrentTeams(c *client.RollbarAPIClient, email string, userID i
 	return currentTeams, nil
 }
 
-func resourceUserRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	email := d.Id()
 	userID := d.Get("user_id").(int)
 	l := log.With().
@@ -323,25 +327,25 @@ func resourceUserRead(_ context.Context, d *schema.ResourceData, meta interface{
 		Int("userID", userID).
 		Logger()
 	l.Info().Msg("Reading rollbar_user resource")
-	c := meta.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarUser)
+	c := meta.(*Meta)
+	ctx = client.WithResource(ctx, rollbarUser)
 	var err error
 
 	// If user ID is not in state, try to query it from Rollbar
 	if userID == 0 {
-		userID, err = c.FindUserID(email)
-		switch err {
-		case nil:
+		userID, err = c.Users.FindUserID(ctx, email)
+		switch {
+		case err == nil:
 			l = log.With().
 				Str("email", email).
 				Int("userID", userID).
 				Logger()
 			l.Debug().Msg("Found registered user")
-		case client.ErrNotFound:
+		case errors.Is(err, client.ErrNotFound):
 			l.Debug().Msg("No registered user found")
 		default:
 			l.Err(err).Send()
-			return diag.FromErr(err)
+			return diagFromErr(err)
 		}
 	}
 
@@ -351,10 +355,10 @@ func resourceUserRead(_ context.Context, d *schema.ResourceData, meta interface{
 	} else {
 		mustSet(d, "status", "registered")
 	}
-	currentTeams, err := resourceUserCurrentTeams(c, email, userID, true)
+	currentTeams, err := resourceUserCurrentTeams(ctx, c, email, userID, true)
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	teamIDs := []int{}
 	for teamID := range currentTeams {
@@ -374,32 +378,35 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		Ints("teamIDs", teamIDs).
 		Logger()
 	l.Info().Msg("Updating rollbar_user resource")
-	return resourceUserCreateOrUpdate(ctx, d, meta)
+	if diags := resourceUserCreateOrUpdate(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+	return resourceUserRead(ctx, d, meta)
 }
 
-func resourceUserDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	email := d.Id()
 	l := log.With().
 		Str("email", email).
 		Logger()
 	l.Info().Msg("Deleting rollbar_user resource")
-	c := meta.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarUser)
+	c := meta.(*Meta)
+	ctx = client.WithResource(ctx, rollbarUser)
 
 	// Try to get user ID
 	userID := d.Get("user_id").(int)
 	if userID == 0 {
-		userID, _ = c.FindUserID(email)
+		userID, _ = c.Users.FindUserID(ctx, email)
 	}
 
-	teamsCurrent, err := resourceUserCurrentTeams(c, email, userID, false)
+	teamsCurrent, err := resourceUserCurrentTeams(ctx, c, email, userID, false)
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 	teamsExpected := make(map[int]bool) // Empty
-	err = resourceUserRemoveTeams(resourceUserAddRemoveTeamsArgs{
-		client:        c,
+	err = resourceUserRemoveTeams(ctx, resourceUserAddRemoveTeamsArgs{
+		meta:          c,
 		email:         email,
 		userID:        userID,
 		teamsCurrent:  teamsCurrent,
@@ -407,7 +414,7 @@ func resourceUserDelete(_ context.Context, d *schema.ResourceData, meta interfac
 	})
 	if err != nil {
 		l.Err(err).Send()
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
 	d.SetId("")
@@ -437,11 +444,11 @@ func resourceUserImporter(ctx context.Context, d *schema.ResourceData, meta inte
 	l.Info().Msg("Importing rollbar_user resource")
 
 	teamIDs := []int{}
-	c := meta.(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-	c.SetHeaderResource(rollbarUser)
+	c := meta.(*Meta)
+	ctx = client.WithResource(ctx, rollbarUser)
 
-	invitations, err := c.FindInvitations(email)
-	if err != nil && err != client.ErrNotFound {
+	invitations, err := c.Invitations.FindInvitations(ctx, email)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
 		l.Err(err).Send()
 		return nil, err
 	}
@@ -451,11 +458,11 @@ func resourceUserImporter(ctx context.Context, d *schema.ResourceData, meta inte
 	for _, inv := range invitations {
 		teamIDs = append(teamIDs, inv.TeamID)
 	}
-	userID, err := c.FindUserID(email)
+	userID, err := c.Users.FindUserID(ctx, email)
 	if err == nil {
 		mustSet(d, "user_id", userID)
 		mustSet(d, "status", "registered")
-		teams, err := c.ListUserTeams(userID)
+		teams, err := c.Users.ListUserTeams(ctx, userID)
 		if err != nil {
 			l.Err(err).Send()
 			return nil, err
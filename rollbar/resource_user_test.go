@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rollbar/terraform-provider-rollbar/client/clienttest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResourceUserCreateOrUpdateRegistered checks that a registered user is
+// added to and removed from teams to match the configured team IDs.
+func TestResourceUserCreateOrUpdateRegistered(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	user := fake.AddUser("jane@example.com", "jane")
+	teamA, _ := fake.CreateTeam(ctx, "team-a", "standard")
+	teamB, _ := fake.CreateTeam(ctx, "team-b", "standard")
+	assert.Nil(t, fake.AssignUserToTeam(ctx, teamA.ID, user.ID))
+
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"email":    user.Email,
+		"team_ids": []interface{}{teamB.ID},
+	})
+	diags := resourceUserCreate(ctx, d, NewMeta(fake, fake))
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, user.Email, d.Id())
+	assert.Equal(t, user.ID, d.Get("user_id"))
+	assert.Equal(t, "registered", d.Get("status"))
+	assert.ElementsMatch(t, []int{teamB.ID}, getTeamIDs(d))
+	inA, _ := fake.IsUserAssignedToTeam(ctx, teamA.ID, user.ID)
+	inB, _ := fake.IsUserAssignedToTeam(ctx, teamB.ID, user.ID)
+	assert.False(t, inA)
+	assert.True(t, inB)
+}
+
+// TestResourceUserCreateOrUpdateInvited checks that an email address with no
+// registered user is invited to the configured teams, and that invitations
+// are canceled when the resource is deleted.
+func TestResourceUserCreateOrUpdateInvited(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	team, _ := fake.CreateTeam(ctx, "team-a", "standard")
+	email := "new.user@example.com"
+
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]interface{}{
+		"email":    email,
+		"team_ids": []interface{}{team.ID},
+	})
+	meta := NewMeta(fake, fake)
+	diags := resourceUserCreate(ctx, d, meta)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "invited", d.Get("status"))
+	assert.ElementsMatch(t, []int{team.ID}, getTeamIDs(d))
+	pending, _ := fake.ListPendingInvitations(ctx, team.ID)
+	assert.Len(t, pending, 1)
+
+	diags = resourceUserDelete(ctx, d, meta)
+	assert.False(t, diags.HasError(), diags)
+	pending, _ = fake.ListPendingInvitations(ctx, team.ID)
+	assert.Empty(t, pending)
+}
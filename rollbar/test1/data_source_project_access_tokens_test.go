@@ -195,3 +195,57 @@ func (s *AccSuite) TestAccProjectAccessTokensDataSourceWithTokensWithPrefix() {
 		},
 	})
 }
+
+// TestAccProjectAccessTokensDataSourceFilters tests reading project access
+// tokens with `rollbar_project_access_tokens` data source filtered by scopes
+// and status, expecting exactly one match.
+func (s *AccSuite) TestAccProjectAccessTokensDataSourceFilters() {
+	rn := "data.rollbar_project_access_tokens.test"
+	// language=hcl
+	tmpl := `
+		resource "rollbar_project" "test" {
+		  name         = "%s"
+		}
+
+		resource "rollbar_project_access_token" "test1" {
+			name = "foo-token"
+			project_id = rollbar_project.test.id
+			scopes = ["read", "post_server_item"]
+		}
+
+		resource "rollbar_project_access_token" "test2" {
+			name = "bar-token"
+			project_id = rollbar_project.test.id
+			scopes = ["post_server_item"]
+			status = "disabled"
+		}
+
+		data "rollbar_project_access_tokens" "test" {
+			project_id = rollbar_project.test.id
+			name_regex = "-token$"
+			scopes = ["post_server_item"]
+			status = "enabled"
+			exactly_one = true
+			depends_on = [
+				rollbar_project_access_token.test1,
+				rollbar_project_access_token.test2,
+			]
+		}
+	`
+	config := fmt.Sprintf(tmpl, s.randName)
+	resource.ParallelTest(s.T(), resource.TestCase{
+		PreCheck:     func() { s.preCheck() },
+		Providers:    s.providers,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					s.checkResourceStateSanity(rn),
+					resource.TestCheckResourceAttr(rn, "access_tokens.#", "1"),
+					resource.TestCheckResourceAttr(rn, "access_tokens.0.name", "foo-token"),
+				),
+			},
+		},
+	})
+}
@@ -23,12 +23,12 @@
 package test1
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"github.com/rollbar/terraform-provider-rollbar/client"
 )
 
 // TestAccProjectsDataSource tests listing of all projects with
@@ -71,8 +71,8 @@ func (s *AccSuite) configDataSourceProjects() string {
 func (s *AccSuite) checkProjectInProjectDataSource(rn string) resource.TestCheckFunc {
 	return func(ts *terraform.State) error {
 		// How many projects should we expect in the project list?
-		c := s.provider.Meta().(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-		pl, err := c.ListProjects()
+		c := s.client()
+		pl, err := c.ListProjects(context.Background())
 		s.Nil(err)
 		expectedCount := strconv.Itoa(len(pl))
 		err = resource.TestCheckResourceAttr(rn, "projects.#", expectedCount)(ts)
@@ -36,6 +36,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rollbar/terraform-provider-rollbar/client/clienttest"
 	"github.com/rollbar/terraform-provider-rollbar/rollbar"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -46,13 +47,17 @@ const schemaKeyToken = "api_key"
 const projectKeyToken = "project_api_key"
 const schemaKeyBaseURL = "api_url"
 
+// testUserEmail is a registered Rollbar user in the account used for
+// acceptance testing.
+const testUserEmail = "terraform-provider-test@rollbar.com"
+
 func init() {
 	// Setup nice logging
 	log.Logger = log.
 		With().Caller().
 		Logger()
 	if os.Getenv("TERRAFORM_PROVIDER_ROLLBAR_DEBUG") == "1" {
-		log.Logger = log.Logger.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+		log.Logger = log.Logger.Output(client.NewRedactingWriter(zerolog.ConsoleWriter{Out: os.Stderr}))
 
 	}
 	zerolog.SetGlobalLevel(zerolog.DebugLevel)
@@ -69,6 +74,10 @@ type AccSuite struct {
 	provider  *schema.Provider
 	providers map[string]*schema.Provider
 
+	// fakeAPI is a local fake of the Rollbar API the suite runs against if
+	// environment variable ROLLBAR_FAKE_API is set; nil otherwise.
+	fakeAPI *clienttest.Server
+
 	// The following variables are populated before each test by SetupTest():
 	randName string // Name of a Rollbar project
 	randID   int    // ID of a Rollbar resource
@@ -82,7 +91,7 @@ func providerConfigureTest(ctx context.Context, d *schema.ResourceData) (interfa
 	baseURL := d.Get(schemaKeyBaseURL).(string)
 	c := client.NewTestClient(baseURL, token)
 	pc := client.NewTestClient(baseURL, projectToken)
-	return map[string]*client.RollbarAPIClient{schemaKeyToken: c, projectKeyToken: pc}, diags
+	return rollbar.NewMeta(c, pc), diags
 }
 func (s *AccSuite) SetupSuite() {
 	maxprocs := runtime.GOMAXPROCS(0)
@@ -96,6 +105,29 @@ func (s *AccSuite) SetupSuite() {
 	s.providers = map[string]*schema.Provider{
 		"rollbar": s.provider,
 	}
+
+	// Optionally run hermetically, against a local fake of the Rollbar API
+	var err error
+	s.fakeAPI, err = clienttest.StartFakeAPI()
+	s.Require().NoError(err)
+	if s.fakeAPI != nil {
+		s.fakeAPI.Fake.AddUser(testUserEmail, "terraform-provider-test")
+	}
+}
+
+func (s *AccSuite) TearDownSuite() {
+	if s.fakeAPI != nil {
+		s.fakeAPI.Close()
+	}
+}
+
+// apiURL returns the base URL of the Rollbar API the acceptance tests run
+// against.
+func apiURL() string {
+	if u := os.Getenv("ROLLBAR_API_URL"); u != "" {
+		return u
+	}
+	return client.DefaultBaseURL
 }
 
 // preCheck ensures we are ready to run the test
@@ -192,7 +224,7 @@ func (s *AccSuite) getResourceAttrInt(ts *terraform.State, resourceName string,
 
 // client returns the current Rollbar API client
 func (s *AccSuite) client() *client.RollbarAPIClient {
-	return s.provider.Meta().(map[string]*client.RollbarAPIClient)[schemaKeyToken]
+	return s.provider.Meta().(*rollbar.Meta).Projects.(*client.RollbarAPIClient)
 }
 
 // getResourceAttrIntSlice returns value of a named attribute of a Terraform
@@ -23,6 +23,7 @@
 package test1
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"regexp"
@@ -96,10 +97,18 @@ func (s *AccSuite) TestAccTokenImport() {
 				Config: config,
 			},
 			{
-				ResourceName:      rn,
-				ImportState:       true,
-				ImportStateIdFunc: importIdProjectAccessToken(rn),
-				ImportStateVerify: true,
+				// By PROJECT-ID,NAME, the resource ID
+				ResourceName:            rn,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotated_at"},
+			},
+			{
+				ResourceName:            rn,
+				ImportState:             true,
+				ImportStateIdFunc:       importIdProjectAccessToken(rn),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotated_at"},
 			},
 		},
 	})
@@ -139,6 +148,7 @@ func (s *AccSuite) TestAccTokenUpdateScope() {
 		}
 	`
 	config2 := fmt.Sprintf(tmpl2, s.randName)
+	var token string // Scopes are updated in place, keeping the token
 	resource.ParallelTest(s.T(), resource.TestCase{
 		PreCheck:     func() { s.preCheck() },
 		Providers:    s.providers,
@@ -149,6 +159,10 @@ func (s *AccSuite) TestAccTokenUpdateScope() {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(rn, "scopes.#", `1`),
 					resource.TestCheckResourceAttr(rn, "scopes.0", "read"),
+					func(ts *terraform.State) error {
+						token = ts.RootModule().Resources[rn].Primary.Attributes["access_token"]
+						return nil
+					},
 				),
 			},
 			{
@@ -158,6 +172,9 @@ func (s *AccSuite) TestAccTokenUpdateScope() {
 					resource.TestCheckResourceAttr(rn, "scopes.#", `1`),
 					resource.TestCheckResourceAttr(rn, "scopes.0", "post_server_item"),
 					s.checkProjectAccessToken(rn),
+					func(ts *terraform.State) error {
+						return resource.TestCheckResourceAttr(rn, "access_token", token)(ts)
+					},
 				),
 			},
 		},
@@ -408,9 +425,9 @@ func (s *AccSuite) TestAccTokenDeleteOnAPIBeforeApply() {
 			// Before running Terraform, delete the token on Rollbar but not in local state
 			{
 				PreConfig: func() {
-					c := client.NewClient(client.DefaultBaseURL, os.Getenv("ROLLBAR_API_KEY"))
+					c := client.NewClient(apiURL(), os.Getenv("ROLLBAR_API_KEY"))
 					var projectID int
-					projects, err := c.ListProjects()
+					projects, err := c.ListProjects(context.Background())
 					s.Nil(err)
 					for _, p := range projects {
 						if p.Name == projectName {
@@ -418,11 +435,11 @@ func (s *AccSuite) TestAccTokenDeleteOnAPIBeforeApply() {
 						}
 					}
 					s.NotZero(projectID)
-					tokens, err := c.ListProjectAccessTokens(projectID)
+					tokens, err := c.ListProjectAccessTokens(context.Background(), projectID)
 					s.Nil(err)
 					for _, t := range tokens {
 						if t.Name == "test-token" {
-							err = c.DeleteProjectAccessToken(projectID, t.AccessToken)
+							err = c.DeleteProjectAccessToken(context.Background(), projectID, t.AccessToken)
 							s.Nil(err)
 							log.Info().
 								Str("token", t.AccessToken).
@@ -459,8 +476,8 @@ func (s *AccSuite) checkProjectAccessToken(resourceName string) resource.TestChe
 		if err != nil {
 			return err
 		}
-		c := s.provider.Meta().(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-		pat, err := c.ReadProjectAccessToken(projectID, accessToken)
+		c := s.client()
+		pat, err := c.ReadProjectAccessToken(context.Background(), projectID, accessToken)
 		if err != nil {
 			return err
 		}
@@ -510,8 +527,8 @@ func (s *AccSuite) checkProjectAccessTokenInTokenList(rn string) resource.TestCh
 		s.Nil(err)
 		projectID, err := s.getResourceAttrInt(ts, rn, "project_id")
 		s.Nil(err)
-		c := s.provider.Meta().(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-		pats, err := c.ListProjectAccessTokens(projectID)
+		c := s.client()
+		pats, err := c.ListProjectAccessTokens(context.Background(), projectID)
 		s.Nil(err)
 		found := false
 		for _, t := range pats {
@@ -524,6 +541,8 @@ func (s *AccSuite) checkProjectAccessTokenInTokenList(rn string) resource.TestCh
 	}
 }
 
+// importIdProjectAccessToken returns the legacy import ID of a project access
+// token, PROJECT-ID/ACCESS-TOKEN.
 func importIdProjectAccessToken(resourceName string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -552,8 +571,8 @@ func (s *AccSuite) checkNoUnexpectedTokens(projectResourceName string, expectedT
 		if err != nil {
 			return err
 		}
-		c := s.provider.Meta().(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-		tokens, err := c.ListProjectAccessTokens(projectID)
+		c := s.client()
+		tokens, err := c.ListProjectAccessTokens(context.Background(), projectID)
 		s.Nil(err)
 		for _, t := range tokens {
 			if !expected[t.Name] {
@@ -23,6 +23,7 @@
 package test1
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -256,12 +257,12 @@ func (s *AccSuite) TestAccProjectDeleteOnAPIBeforeApply() {
 			// Before running Terraform, delete the project on Rollbar but not in local state
 			{
 				PreConfig: func() {
-					c := client.NewClient(client.DefaultBaseURL, os.Getenv("ROLLBAR_API_KEY"))
-					projects, err := c.ListProjects()
+					c := client.NewClient(apiURL(), os.Getenv("ROLLBAR_API_KEY"))
+					projects, err := c.ListProjects(context.Background())
 					s.Nil(err)
 					for _, p := range projects {
 						if p.Name == s.randName {
-							err = c.DeleteProject(p.ID)
+							err = c.DeleteProject(context.Background(), p.ID)
 							s.Nil(err)
 							log.Info().
 								Str("project_name", s.randName).
@@ -301,8 +302,8 @@ func (s *AccSuite) checkProjectExists(rn string, name string) resource.TestCheck
 	return func(ts *terraform.State) error {
 		id, err := s.getResourceIDInt(ts, rn)
 		s.Nil(err)
-		c := s.provider.Meta().(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-		proj, err := c.ReadProject(id)
+		c := s.client()
+		proj, err := c.ReadProject(context.Background(), id)
 		s.Nil(err)
 		s.Equal(name, proj.Name, "project name from API does not match project name in Terraform config")
 		return nil
@@ -315,8 +316,8 @@ func (s *AccSuite) checkProjectInProjectList(rn string) resource.TestCheckFunc {
 	return func(ts *terraform.State) error {
 		id, err := s.getResourceIDInt(ts, rn)
 		s.Nil(err)
-		c := s.provider.Meta().(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-		projList, err := c.ListProjects()
+		c := s.client()
+		projList, err := c.ListProjects(context.Background())
 		s.Nil(err)
 		found := false
 		for _, proj := range projList {
@@ -334,8 +335,8 @@ func (s *AccSuite) checkProjectInProjectList(rn string) resource.TestCheckFunc {
 func sweepResourceProject(_ string) error {
 	log.Info().Msg("Cleaning up Rollbar projects from acceptance test runs.")
 
-	c := client.NewClient(client.DefaultBaseURL, os.Getenv("ROLLBAR_API_KEY"))
-	projects, err := c.ListProjects()
+	c := client.NewClient(apiURL(), os.Getenv("ROLLBAR_API_KEY"))
+	projects, err := c.ListProjects(context.Background())
 	if err != nil {
 		log.Err(err).Send()
 		return err
@@ -348,7 +349,7 @@ func sweepResourceProject(_ string) error {
 			Int("id", p.ID).
 			Logger()
 		if strings.HasPrefix(p.Name, "tf-acc-test-") {
-			err = c.DeleteProject(p.ID)
+			err = c.DeleteProject(context.Background(), p.ID)
 			if err != nil {
 				l.Err(err).Send()
 				return err
@@ -371,7 +372,7 @@ func (s *AccSuite) checkProjectTeams(projectResourceName string) resource.TestCh
 		s.Nil(err)
 		expected, err := s.getResourceAttrIntSlice(ts, projectResourceName, "team_ids")
 		s.Nil(err)
-		actual, err := s.client().FindProjectTeamIDs(projectID)
+		actual, err := s.client().FindProjectTeamIDs(context.Background(), projectID)
 		s.Nil(err)
 		s.ElementsMatch(expected, actual)
 		return nil
@@ -1,6 +1,7 @@
 package test1
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"regexp"
@@ -216,12 +217,12 @@ func (s *AccSuite) TestAccTeamDeleteOnAPIBeforeApply() {
 			// Before running Terraform, delete the team on Rollbar but not in local state
 			{
 				PreConfig: func() {
-					c := client.NewClient(client.DefaultBaseURL, os.Getenv("ROLLBAR_API_KEY"))
-					teams, err := c.ListCustomTeams()
+					c := client.NewClient(apiURL(), os.Getenv("ROLLBAR_API_KEY"))
+					teams, err := c.ListCustomTeams(context.Background())
 					s.Nil(err)
 					for _, t := range teams {
 						if t.Name == teamName1 {
-							err = c.DeleteTeam(t.ID)
+							err = c.DeleteTeam(context.Background(), t.ID)
 							s.Nil(err)
 							log.Info().
 								Str("team_name", teamName1).
@@ -247,8 +248,8 @@ func (s *AccSuite) checkTeam(rn, teamName, accessLevel string) resource.TestChec
 	return func(ts *terraform.State) error {
 		id, err := s.getResourceIDInt(ts, rn)
 		s.Nil(err)
-		c := s.provider.Meta().(map[string]*client.RollbarAPIClient)[schemaKeyToken]
-		t, err := c.ReadTeam(id)
+		c := s.client()
+		t, err := c.ReadTeam(context.Background(), id)
 		s.Nil(err)
 		s.Equal(teamName, t.Name, "team name from API does not match team name in Terraform config")
 		s.Equal(accessLevel, t.AccessLevel)
@@ -260,8 +261,8 @@ func (s *AccSuite) checkTeam(rn, teamName, accessLevel string) resource.TestChec
 func sweepResourceTeam(_ string) error {
 	log.Info().Msg("Cleaning up Rollbar teams from acceptance test runs.")
 
-	c := client.NewClient(client.DefaultBaseURL, os.Getenv("ROLLBAR_API_KEY"))
-	teams, err := c.ListCustomTeams()
+	c := client.NewClient(apiURL(), os.Getenv("ROLLBAR_API_KEY"))
+	teams, err := c.ListCustomTeams(context.Background())
 	if err != nil {
 		log.Err(err).Send()
 		return err
@@ -274,7 +275,7 @@ func sweepResourceTeam(_ string) error {
 			Int("id", t.ID).
 			Logger()
 		if strings.HasPrefix(t.Name, "tf-acc-test-") {
-			err = c.DeleteTeam(t.ID)
+			err = c.DeleteTeam(context.Background(), t.ID)
 			if err != nil {
 				l.Err(err).Send()
 				return err
@@ -356,7 +357,7 @@ func (s *AccSuite) checkTeamIsDeleted(teamName string) resource.TestCheckFunc {
 		l := log.With().Str("team_name", teamName).Logger()
 		l.Info().Msg("Checking that team is deleted")
 		c := s.client()
-		teams, err := c.ListCustomTeams()
+		teams, err := c.ListCustomTeams(context.Background())
 		s.Nil(err)
 		for _, t := range teams {
 			if t.Name == teamName {
@@ -23,6 +23,7 @@
 package test2
 
 import (
+	"context"
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -262,27 +263,27 @@ func (s *AccSuite) TestNotificationCreateSpecialEmail() {
 func sweepResourceNotification(_ string) error {
 	log.Info().Msg("Cleaning up Rollbar notifications from acceptance test runs.")
 
-	c := client.NewClient(client.DefaultBaseURL, os.Getenv("ROLLBAR_PROJECT_API_KEY"))
-	notifications, err := c.ListNotifications("webhook")
+	c := client.NewClient(apiURL(), os.Getenv("ROLLBAR_PROJECT_API_KEY"))
+	notifications, err := c.ListNotifications(context.Background(), "webhook")
 	if err != nil {
 		log.Err(err).Send()
 		return err
 	}
 	for _, n := range notifications {
-		err = c.DeleteNotification(n.ID, "webhook")
+		err = c.DeleteNotification(context.Background(), n.ID, "webhook")
 		if err != nil {
 			log.Err(err).Send()
 			return err
 		}
 	}
 
-	notifications, err = c.ListNotifications("email")
+	notifications, err = c.ListNotifications(context.Background(), "email")
 	if err != nil {
 		log.Err(err).Send()
 		return err
 	}
 	for _, n := range notifications {
-		err = c.DeleteNotification(n.ID, "email")
+		err = c.DeleteNotification(context.Background(), n.ID, "email")
 		if err != nil {
 			log.Err(err).Send()
 			return err
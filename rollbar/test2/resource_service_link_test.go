@@ -23,6 +23,7 @@
 package test2
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -114,14 +115,14 @@ func (s *AccSuite) TestServiceLinkUpdate() {
 func sweepResourceServiceLink(_ string) error {
 	log.Info().Msg("Cleaning up Rollbar service links from acceptance test runs.")
 
-	c := client.NewClient(client.DefaultBaseURL, os.Getenv("ROLLBAR_PROJECT_API_KEY"))
-	serviceLinks, err := c.ListSerivceLinks()
+	c := client.NewClient(apiURL(), os.Getenv("ROLLBAR_PROJECT_API_KEY"))
+	serviceLinks, err := c.ListSerivceLinks(context.Background())
 	if err != nil {
 		log.Err(err).Send()
 		return err
 	}
 	for _, s := range serviceLinks {
-		err = c.DeleteServiceLink(s.ID)
+		err = c.DeleteServiceLink(context.Background(), s.ID)
 		if err != nil {
 			log.Err(err).Send()
 			return err
@@ -1,6 +1,8 @@
 package test2
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -12,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rollbar/terraform-provider-rollbar/client/clienttest"
 	"github.com/rs/zerolog/log"
 )
 
@@ -511,7 +514,7 @@ func (s *AccSuite) checkUserTeams(resourceName string) resource.TestCheckFunc {
 
 		// Check team memberships, if state contains a Rollbar user ID.
 		if userID, err2 := s.getResourceAttrInt(ts, resourceName, "user_id"); err2 == nil {
-			currentTeams, err3 := c.ListUserCustomTeams(userID)
+			currentTeams, err3 := c.ListUserCustomTeams(context.Background(), userID)
 			s.Nil(err3)
 			for teamID := range teamFound {
 				// Did we find an expected team?
@@ -531,8 +534,8 @@ func (s *AccSuite) checkUserTeams(resourceName string) resource.TestCheckFunc {
 			Msg("Existing team memberships")
 
 		// Check invitations
-		invitations, err4 := c.FindPendingInvitations(email)
-		if err4 != nil && err4 != client.ErrNotFound {
+		invitations, err4 := c.FindPendingInvitations(context.Background(), email)
+		if err4 != nil && !errors.Is(err4, client.ErrNotFound) {
 			s.Nil(err4)
 		}
 		// Did we find any expected teams?
@@ -565,7 +568,7 @@ func (s *AccSuite) checkUserTeams(resourceName string) resource.TestCheckFunc {
 		// Error if any unexpected team was found
 		if len(unexpectedTeams) != 0 {
 			for _, teamID := range unexpectedTeams {
-				t, err := c.ReadTeam(teamID)
+				t, err := c.ReadTeam(context.Background(), teamID)
 				s.Nil(err)
 				l.Error().
 					Interface("team", t).
@@ -675,11 +678,11 @@ func (s *AccSuite) checkUserIsOnTeam(userEmail, teamName string) resource.TestCh
 		c := s.client()
 
 		// Find user ID
-		userID, err := c.FindUserID(userEmail)
+		userID, err := c.FindUserID(context.Background(), userEmail)
 		s.Nil(err)
 		s.NotZero(userID)
 
-		teams, err := c.ListUserTeams(userID)
+		teams, err := c.ListUserTeams(context.Background(), userID)
 		s.Nil(err)
 		for _, t := range teams {
 			if t.Name == teamName {
@@ -703,9 +706,9 @@ func (s *AccSuite) checkUserIsNotOnTeam(userEmail, teamName string) resource.Tes
 		l.Info().Msg("Checking that user is not member of team")
 		c := s.client()
 
-		userID, err := c.FindUserID(userEmail)
+		userID, err := c.FindUserID(context.Background(), userEmail)
 		s.Nil(err)
-		teams, err := c.ListUserTeams(userID)
+		teams, err := c.ListUserTeams(context.Background(), userID)
 		s.Nil(err)
 		for _, t := range teams {
 			if t.Name == teamName {
@@ -729,9 +732,9 @@ func (s *AccSuite) checkUserIsInvited(userEmail, teamName string) resource.TestC
 		l.Info().Msg("Checking that user has been invited to team")
 		c := s.client()
 
-		teamID, err := c.FindTeamID(teamName)
+		teamID, err := c.FindTeamID(context.Background(), teamName)
 		s.Nil(err)
-		invitations, err := c.ListPendingInvitations(teamID)
+		invitations, err := c.ListPendingInvitations(context.Background(), teamID)
 		s.Nil(err)
 		for _, inv := range invitations {
 			if inv.ToEmail == userEmail {
@@ -755,9 +758,9 @@ func (s *AccSuite) checkUserIsNotInvited(userEmail, teamName string) resource.Te
 		l.Info().Msg("Checking that user is not invited to team")
 		c := s.client()
 
-		teamID, err := c.FindTeamID(teamName)
+		teamID, err := c.FindTeamID(context.Background(), teamName)
 		s.Nil(err)
-		invitations, err := c.ListPendingInvitations(teamID)
+		invitations, err := c.ListPendingInvitations(context.Background(), teamID)
 		s.Nil(err)
 		for _, inv := range invitations {
 			if inv.ToEmail == userEmail {
@@ -832,6 +835,7 @@ func (s *AccSuite) TestAccUserInvitedToRegistered() {
 					r, err := recorder.New("vcr/registered_user")
 					s.Nil(err)
 					r.AddFilter(vcrFilterHeaders)
+					r.SetMatcher(clienttest.MatchRedacted)
 					http.DefaultTransport = r
 
 				},
@@ -848,9 +852,13 @@ func (s *AccSuite) TestAccUserInvitedToRegistered() {
 	http.DefaultTransport = origTransport
 }
 
-// vcrFilterHeaders removes unnecessary headers from VCR recordings.
+// vcrFilterHeaders masks secrets in, and removes unnecessary headers from, VCR
+// recordings.
 func vcrFilterHeaders(i *cassette.Interaction) error {
-	delete(i.Request.Headers, "X-Rollbar-Access-Token")
+	err := clienttest.RedactInteraction(i)
+	if err != nil {
+		return err
+	}
 	delete(i.Request.Headers, "User-Agent")
 	for key := range i.Response.Headers {
 		deleteHeader := false
@@ -875,8 +883,8 @@ func vcrFilterHeaders(i *cassette.Interaction) error {
 func sweepResourceUser(_ string) error {
 	log.Info().Msg("Cleaning up Rollbar users from acceptance test runs.")
 
-	c := client.NewClient(client.DefaultBaseURL, os.Getenv("ROLLBAR_API_KEY"))
-	users, err := c.ListTestUsers()
+	c := client.NewClient(apiURL(), os.Getenv("ROLLBAR_API_KEY"))
+	users, err := c.ListTestUsers(context.Background())
 	if err != nil {
 		log.Err(err).Send()
 		return err
@@ -884,7 +892,7 @@ func sweepResourceUser(_ string) error {
 
 	// Find the ID for this account's "Everyone" team
 	var everyoneTeamID int
-	teams, err := c.ListTeams()
+	teams, err := c.ListTeams(context.Background())
 	if err != nil {
 		log.Err(err).Send()
 		return err
@@ -908,7 +916,7 @@ func sweepResourceUser(_ string) error {
 		}
 
 		// Remove the user from Everyone team, thereby removing it from the account.
-		err = c.RemoveUserFromTeam(u.ID, everyoneTeamID)
+		err = c.RemoveUserFromTeam(context.Background(), u.ID, everyoneTeamID)
 		if err != nil {
 			log.Err(err).Send()
 			return err
@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rs/zerolog/log"
+)
+
+// timeNow returns the current time.  Tests replace it to make rotations due.
+var timeNow = time.Now
+
+// parseRotationDuration parses a duration such as "2160h", or a number of days
+// such as "90d".
+func parseRotationDuration(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// validateRotationDuration checks that a string attribute is a non-negative
+// duration accepted by parseRotationDuration.
+func validateRotationDuration(v interface{}, p cty.Path) diag.Diagnostics {
+	d, err := parseRotationDuration(v.(string))
+	if err != nil || d < 0 {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid duration",
+			Detail:        fmt.Sprintf(`%q is not a duration such as "90d" or "2160h"`, v),
+			AttributePath: p,
+		}}
+	}
+	return nil
+}
+
+// rotationDue reports whether the token is older than its rotate_after.  The
+// age of a token imported or created before rotation was supported counts
+// from date_created.
+func rotationDue(d *schema.ResourceDiff) bool {
+	after, err := parseRotationDuration(d.Get("rotate_after").(string))
+	if err != nil || after <= 0 {
+		return false
+	}
+	since := int64(d.Get("rotated_at").(int))
+	if since == 0 {
+		since = int64(d.Get("date_created").(int))
+	}
+	if since == 0 {
+		return false
+	}
+	return !timeNow().Before(time.Unix(since, 0).Add(after))
+}
+
+// customizeProjectAccessTokenDiff plans a rotation of the token when its
+// keepers change or it is due by rotate_after, so that the new access_token is
+// known to be changing.  Otherwise it plans the deletion of a previous token
+// whose grace period has passed.  A token whose access_token is discarded is
+// not rotated, since the previous token would be kept in state.
+func customizeProjectAccessTokenDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	discard := d.Get("discard_access_token").(bool)
+	if discard && (len(d.Get("keepers").(map[string]interface{})) > 0 || d.Get("rotate_after").(string) != "") {
+		return fmt.Errorf("keepers and rotate_after cannot be used with discard_access_token")
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	rotate := d.HasChange("keepers") || rotationDue(d)
+	if d.HasChange("discard_access_token") {
+		if rotate {
+			return fmt.Errorf("discard_access_token cannot change while the token is rotated; apply the rotation first")
+		}
+		if err := d.SetNewComputed("access_token"); err != nil {
+			return err
+		}
+		if discard && d.Get("previous_access_token").(string) != "" {
+			// Delete the previous token now, rather than keep it in state
+			if err := d.SetNew("previous_access_token", ""); err != nil {
+				return err
+			}
+			return d.SetNew("previous_token_delete_after", 0)
+		}
+		return nil
+	}
+	if rotate {
+		for _, k := range []string{"access_token", "previous_access_token", "rotated_at", "previous_token_delete_after"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	deleteAfter := int64(d.Get("previous_token_delete_after").(int))
+	if d.Get("previous_access_token").(string) != "" && timeNow().Unix() >= deleteAfter {
+		if err := d.SetNew("previous_access_token", ""); err != nil {
+			return err
+		}
+		return d.SetNew("previous_token_delete_after", 0)
+	}
+	return nil
+}
+
+// rotateProjectAccessToken replaces the token with a new one, configured as in
+// d, and keeps the old token, still enabled, as previous_access_token for the
+// grace period, so that consumers not yet given the new token keep working.
+// An older previous token is then deleted; if that fails, it is left in
+// Rollbar with a warning.
+func rotateProjectAccessToken(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	oldToken, _ := d.GetChange("access_token")
+	oldPrevious, _ := d.GetChange("previous_access_token")
+	args := projectAccessTokenCreateArgs(d)
+	l := log.With().
+		Int("project_id", args.ProjectID).
+		Str("name", args.Name).
+		Logger()
+	l.Info().Msg("Rotating project access token")
+
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarProjectAccessToken)
+	pat, err := c.ProjectAccessTokens.CreateProjectAccessToken(ctx, args)
+	if err != nil {
+		// Keep the prior state, including keepers, so the next apply retries
+		d.Partial(true)
+		return diagFromErr(err)
+	}
+	grace, _ := parseRotationDuration(d.Get("rotation_grace_period").(string))
+	now := timeNow()
+	mustSet(d, "access_token", pat.AccessToken)
+	mustSet(d, "previous_access_token", oldToken)
+	mustSet(d, "rotated_at", now.Unix())
+	mustSet(d, "previous_token_delete_after", now.Add(grace).Unix())
+
+	var diags diag.Diagnostics
+	if p := oldPrevious.(string); p != "" {
+		err = c.ProjectAccessTokens.DeleteProjectAccessToken(ctx, args.ProjectID, p)
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			l.Warn().Err(err).Msg("Unable to delete older previous project access token")
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unable to delete older project access token",
+				Detail: fmt.Sprintf("An access token named %q, replaced by an earlier rotation, is left in project %d: %s",
+					args.Name, args.ProjectID, err),
+			})
+		}
+	}
+	l.Info().Msg("Rotated project access token; previous token kept until the grace period ends")
+	return append(diags, resourceProjectAccessTokenRead(client.WithNotFoundRetry(ctx), d, m)...)
+}
+
+// deletePreviousProjectAccessToken deletes the token replaced by the last
+// rotation, if any.
+func deletePreviousProjectAccessToken(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	previous, _ := d.GetChange("previous_access_token")
+	if previous.(string) == "" {
+		return nil
+	}
+	projectID := d.Get("project_id").(int)
+	log.Info().Int("project_id", projectID).Msg("Deleting previous project access token")
+	c := m.(*Meta)
+	ctx = client.WithResource(ctx, rollbarProjectAccessToken)
+	err := c.ProjectAccessTokens.DeleteProjectAccessToken(ctx, projectID, previous.(string))
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		return diagFromErr(err)
+	}
+	mustSet(d, "previous_access_token", "")
+	mustSet(d, "previous_token_delete_after", 0)
+	return nil
+}
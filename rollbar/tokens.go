@@ -0,0 +1,358 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rs/zerolog/log"
+)
+
+// projectTokenTypes are the resources that call the API with
+// project_api_key.  All other resources and data sources use api_key.
+var projectTokenTypes = map[string]bool{
+	rollbarNotification: true,
+	rollbarIntegration:  true,
+	rollbarServiceLink:  true,
+}
+
+// tokenEnvVars are the environment variables from which the token arguments
+// default.
+var tokenEnvVars = map[string]string{
+	schemaKeyToken:  "ROLLBAR_API_KEY",
+	projectKeyToken: "ROLLBAR_PROJECT_API_KEY",
+}
+
+// tokenKeyFor returns the provider argument holding the token needed by the
+// named resource or data source.
+func tokenKeyFor(name string) string {
+	if projectTokenTypes[name] {
+		return projectKeyToken
+	}
+	return schemaKeyToken
+}
+
+// tokenValidation is what configure-time validation learned about the
+// configured tokens.
+type tokenValidation struct {
+	accountID int // Account of api_key; zero if unknown
+}
+
+// tokenConfig is the access tokens configured for the provider, and the
+// clients authenticated with them.
+type tokenConfig struct {
+	token               string // api_key
+	projectToken        string // project_api_key
+	api                 client.RollbarAPI
+	projectAPI          client.RollbarAPI
+	projectTokens       map[int]string // project_api_keys
+	projectAPIs         map[int]client.RollbarAPI
+	deriveProjectTokens bool
+}
+
+// validateTokens checks each configured token with one cheap API call:
+// listing teams for api_key, which needs an account access token, and listing
+// service links for project_api_key, which needs a project access token.  A
+// token that fails is tried once against the other kind of endpoint, to tell
+// a token in the wrong argument from an invalid one.  The project of a valid
+// project_api_key is read with the token itself and, with a working api_key,
+// its name and the token's scopes are looked up in that project.  Each token
+// in project_api_keys is looked up with api_key among the access tokens of
+// its project, or, without a working api_key, checked like project_api_key.
+func validateTokens(ctx context.Context, tc tokenConfig) (tokenValidation, diag.Diagnostics) {
+	api, projectAPI := tc.api, tc.projectAPI
+	token, projectToken, projectTokens := tc.token, tc.projectToken, tc.projectTokens
+	var tv tokenValidation
+	var diags diag.Diagnostics
+	if token == "" && projectToken == "" && len(projectTokens) == 0 {
+		return tv, diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "No Rollbar API token configured",
+			Detail:   fmt.Sprintf("Neither %s nor %s is set, so every resource and data source will fail.", schemaKeyToken, projectKeyToken),
+		}}
+	}
+
+	accountOK := false
+	if token == "" {
+		diags = append(diags, missingTokenWarning(schemaKeyToken, "all resources and data sources except "+projectTokenTypeList()))
+	} else {
+		teams, err := api.ListTeams(ctx)
+		switch {
+		case err == nil:
+			accountOK = true
+			for _, t := range teams {
+				if t.AccountID != 0 {
+					tv.accountID = t.AccountID
+					break
+				}
+			}
+			log.Info().
+				Int("account_id", tv.accountID).
+				Msgf("%s is an account access token with read scope", schemaKeyToken)
+		case isAuthError(err) && worksAsProjectToken(ctx, api):
+			diags = append(diags, wrongTokenKind(schemaKeyToken, "a project", "an account", projectKeyToken))
+		default:
+			diags = append(diags, invalidToken(schemaKeyToken, err))
+		}
+	}
+
+	switch {
+	case projectToken != "":
+		pDiags := checkProjectToken(ctx, projectKeyToken, projectAPI)
+		diags = append(diags, pDiags...)
+		if !pDiags.HasError() {
+			describeProjectToken(ctx, api, projectAPI, accountOK, projectToken)
+		}
+	case len(projectTokens) == 0 && !tc.deriveProjectTokens:
+		diags = append(diags, missingTokenWarning(projectKeyToken, projectTokenTypeList()))
+	}
+
+	ids := make([]int, 0, len(projectTokens))
+	for id := range projectTokens {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		key := fmt.Sprintf("%s[%q]", schemaKeyProjectTokens, strconv.Itoa(id))
+		if accountOK {
+			diags = append(diags, checkTokenProject(ctx, api, key, id, projectTokens[id])...)
+			continue
+		}
+		diags = append(diags, checkProjectToken(ctx, key, tc.projectAPIs[id])...)
+	}
+	return tv, diags
+}
+
+// checkProjectToken checks that projectAPI's token, from argument key, is a
+// valid project access token, by listing service links.
+func checkProjectToken(ctx context.Context, key string, projectAPI client.RollbarAPI) diag.Diagnostics {
+	_, err := projectAPI.ListSerivceLinks(ctx)
+	switch {
+	case err == nil:
+		log.Info().Msgf("%s is a project access token with read scope", key)
+		return nil
+	case errors.Is(err, client.ErrForbidden):
+		// Valid, but without read scope
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Rollbar %s lacks read scope", key),
+			Detail:   fmt.Sprintf("%s could not read service links: %s. Reading %s will fail.", key, err, projectTokenTypeList()),
+		}}
+	case isAuthError(err) && worksAsAccountToken(ctx, projectAPI):
+		return diag.Diagnostics{wrongTokenKind(key, "an account", "a project", schemaKeyToken)}
+	default:
+		return diag.Diagnostics{invalidToken(key, err)}
+	}
+}
+
+// checkTokenProject checks that projectToken, from argument key, is an access
+// token of the project with the given ID, by listing that project's access
+// tokens with api.
+func checkTokenProject(ctx context.Context, api client.RollbarAPI, key string, projectID int, projectToken string) diag.Diagnostics {
+	pats, err := api.ListProjectAccessTokens(ctx, projectID)
+	if errors.Is(err, client.ErrNotFound) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Rollbar project %d not found", projectID),
+			Detail:   fmt.Sprintf("%s names project %d, which does not exist in the account of %s.", key, projectID, schemaKeyToken),
+		}}
+	}
+	if err != nil {
+		log.Debug().Err(err).Int("project_id", projectID).Msg("Unable to list project access tokens")
+		return nil
+	}
+	for _, pat := range pats {
+		if pat.AccessToken != projectToken {
+			continue
+		}
+		log.Info().
+			Int("project_id", projectID).
+			Str("token_name", pat.Name).
+			Interface("scopes", pat.Scopes).
+			Str("status", string(pat.Status)).
+			Msgf("%s is a project access token", key)
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Rollbar %s belongs to another project", key),
+		Detail:   fmt.Sprintf("%s is not an access token of project %d. Resources with project_id %d would act on the wrong project.", key, projectID, projectID),
+	}}
+}
+
+// describeProjectToken logs the project of projectAPI's token, projectToken,
+// read from the project's Slack integration settings.  If accountOK, the
+// project's name and the token's name, scopes and status are read with api.
+func describeProjectToken(ctx context.Context, api, projectAPI client.RollbarAPI, accountOK bool, projectToken string) {
+	projectID, err := tokenProjectID(ctx, projectAPI)
+	if err != nil {
+		log.Debug().Err(err).Msgf("Unable to read the project of %s", projectKeyToken)
+		return
+	}
+	lc := log.With().Int("project_id", projectID)
+	if accountOK {
+		if p, err := api.ReadProject(ctx, projectID); err == nil {
+			lc = lc.Str("project_name", p.Name)
+		}
+		if pat, err := api.ReadProjectAccessToken(ctx, projectID, projectToken); err == nil {
+			lc = lc.Str("token_name", pat.Name).
+				Interface("scopes", pat.Scopes).
+				Str("status", string(pat.Status))
+		}
+	}
+	l := lc.Logger()
+	l.Info().Msgf("%s is an access token of project %d", projectKeyToken, projectID)
+}
+
+// tokenProjectID returns the ID of the project to which projectAPI's token
+// belongs, read from the project's Slack integration settings.
+func tokenProjectID(ctx context.Context, projectAPI client.IntegrationsAPI) (int, error) {
+	i, err := projectAPI.ReadIntegration(ctx, client.SLACK)
+	if err != nil {
+		return 0, err
+	}
+	slack, ok := i.(*client.SlackIntegration)
+	if !ok || slack.ProjectID == 0 {
+		return 0, errors.New("no project ID in Slack integration settings")
+	}
+	return int(slack.ProjectID), nil
+}
+
+// isAuthError reports whether err means the API rejected the token.
+func isAuthError(err error) bool {
+	return errors.Is(err, client.ErrUnauthorized) || errors.Is(err, client.ErrForbidden)
+}
+
+// worksAsProjectToken reports whether api's token can call a project level
+// endpoint.
+func worksAsProjectToken(ctx context.Context, api client.RollbarAPI) bool {
+	_, err := api.ListSerivceLinks(ctx)
+	return err == nil
+}
+
+// worksAsAccountToken reports whether api's token can call an account level
+// endpoint.
+func worksAsAccountToken(ctx context.Context, api client.RollbarAPI) bool {
+	_, err := api.ListTeams(ctx)
+	return err == nil
+}
+
+// projectTokenTypeList names the resources that use project_api_key.
+func projectTokenTypeList() string {
+	return strings.Join([]string{rollbarNotification, rollbarIntegration, rollbarServiceLink}, ", ")
+}
+
+// missingTokenWarning warns that the token in argument key is not set, so the
+// resources and data sources named by users will fail.
+func missingTokenWarning(key, users string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Rollbar %s not configured", key),
+		Detail:   fmt.Sprintf("%s is not set, so %s will fail.", key, users),
+	}
+}
+
+// wrongTokenKind reports that the token in argument key is the wrong kind of
+// access token.
+func wrongTokenKind(key, is, want, otherKey string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Rollbar %s is %s access token", key, is),
+		Detail:   fmt.Sprintf("%s must be %s access token. The token given may belong in %s.", key, want, otherKey),
+	}
+}
+
+// invalidToken reports that the token in argument key failed validation with
+// err.
+func invalidToken(key string, err error) diag.Diagnostic {
+	d := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Invalid Rollbar %s", key),
+		Detail:   fmt.Sprintf("%s was rejected by the Rollbar API: %s", key, err),
+	}
+	var ae *client.APIError
+	if errors.As(err, &ae) {
+		if hint := ae.Hint(); hint != "" {
+			d.Detail += fmt.Sprintf(". Hint: %s", hint)
+		}
+	} else {
+		d.Summary = fmt.Sprintf("Unable to validate Rollbar %s", key)
+	}
+	return d
+}
+
+// derivedTokenName is the name of the project access tokens created by
+// derive_project_tokens.
+const derivedTokenName = "terraform-provider-rollbar"
+
+// deriveProjectToken returns an enabled access token of the project with the
+// given ID that has all of scopes, creating one named derivedTokenName if the
+// project has none.
+func deriveProjectToken(ctx context.Context, api client.ProjectAccessTokensAPI, projectID int, scopes []client.Scope) (string, error) {
+	l := log.With().Int("project_id", projectID).Interface("scopes", scopes).Logger()
+	pats, err := api.ListProjectAccessTokens(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+	for _, pat := range pats {
+		if pat.Status == client.StatusEnabled && hasScopes(pat.Scopes, scopes) {
+			l.Info().Str("token_name", pat.Name).Msg("Using existing project access token")
+			return pat.AccessToken, nil
+		}
+	}
+	pat, err := api.CreateProjectAccessToken(ctx, client.ProjectAccessTokenCreateArgs{
+		ProjectID: projectID,
+		Name:      derivedTokenName,
+		Scopes:    scopes,
+		Status:    client.StatusEnabled,
+	})
+	if err != nil {
+		return "", err
+	}
+	l.Info().Str("token_name", pat.Name).Msg("Created project access token")
+	return pat.AccessToken, nil
+}
+
+// hasScopes reports whether have includes every scope in want.
+func hasScopes(have, want []client.Scope) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			if h == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,294 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rollbar/terraform-provider-rollbar/client/clienttest"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// projectOnlyFake is a Fake whose token is a project access token: account
+// level endpoints reject it.
+type projectOnlyFake struct {
+	*clienttest.Fake
+}
+
+func (f projectOnlyFake) ListTeams(context.Context) ([]client.Team, error) {
+	return nil, &client.APIError{StatusCode: http.StatusUnauthorized}
+}
+
+// rejectedFake is a Fake whose token is not valid at all.
+type rejectedFake struct {
+	projectOnlyFake
+}
+
+func (f rejectedFake) ListSerivceLinks(context.Context) ([]client.ServiceLink, error) {
+	return nil, &client.APIError{StatusCode: http.StatusUnauthorized}
+}
+
+// summaries returns the severity and summary of each diagnostic.
+func summaries(diags diag.Diagnostics) []string {
+	var ss []string
+	for _, d := range diags {
+		sev := "error"
+		if d.Severity == diag.Warning {
+			sev = "warning"
+		}
+		ss = append(ss, sev+": "+d.Summary)
+	}
+	return ss
+}
+
+// TestValidateTokens checks the diagnostics for valid, missing, invalid and
+// misplaced tokens.
+func TestValidateTokens(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	pats, err := fake.ListProjectAccessTokens(ctx, p.ID)
+	require.NoError(t, err)
+	projectToken := pats[0].AccessToken
+
+	tv, diags := validateTokens(ctx, tokenConfig{api: fake, projectAPI: fake, token: "account", projectToken: projectToken})
+	assert.Empty(t, diags)
+	assert.Equal(t, clienttest.AccountID, tv.accountID)
+
+	_, diags = validateTokens(ctx, tokenConfig{api: fake, projectAPI: fake})
+	assert.Equal(t, []string{"warning: No Rollbar API token configured"}, summaries(diags))
+
+	_, diags = validateTokens(ctx, tokenConfig{api: fake, projectAPI: fake, token: "account"})
+	assert.Equal(t, []string{"warning: Rollbar project_api_key not configured"}, summaries(diags))
+	assert.Contains(t, diags[0].Detail, rollbarNotification)
+
+	_, diags = validateTokens(ctx, tokenConfig{api: fake, projectAPI: fake, token: "account", deriveProjectTokens: true})
+	assert.Empty(t, diags)
+
+	_, diags = validateTokens(ctx, tokenConfig{api: projectOnlyFake{fake}, projectAPI: fake, token: projectToken, projectToken: projectToken})
+	assert.Equal(t, []string{"error: Rollbar api_key is a project access token"}, summaries(diags))
+
+	_, diags = validateTokens(ctx, tokenConfig{api: fake, projectAPI: rejectedFake{projectOnlyFake{fake}}, token: "account", projectToken: "typo"})
+	assert.Equal(t, []string{"error: Invalid Rollbar project_api_key"}, summaries(diags))
+}
+
+// slackFake is a Fake whose token belongs to the project with the given ID,
+// as reported by the project's Slack integration.
+type slackFake struct {
+	*clienttest.Fake
+	projectID int
+}
+
+func (f slackFake) ReadIntegration(_ context.Context, integration string) (interface{}, error) {
+	if integration != client.SLACK {
+		return nil, client.ErrNotFound
+	}
+	return &client.SlackIntegration{ProjectID: int64(f.projectID)}, nil
+}
+
+// TestDescribeProjectToken checks that the project, scopes and status of
+// project_api_key are reported, and only the project without api_key.
+func TestDescribeProjectToken(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+	pats, err := fake.ListProjectAccessTokens(ctx, p.ID)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	orig := log.Logger
+	log.Logger = zerolog.New(&buf)
+	defer func() { log.Logger = orig }()
+
+	projectAPI := slackFake{fake, p.ID}
+	describeProjectToken(ctx, fake, projectAPI, true, pats[0].AccessToken)
+	assert.Contains(t, buf.String(), fmt.Sprintf(`"project_id":%d`, p.ID))
+	assert.Contains(t, buf.String(), `"project_name":"foo"`)
+	assert.Contains(t, buf.String(), fmt.Sprintf(`"token_name":%q`, pats[0].Name))
+	assert.Contains(t, buf.String(), `"status":"enabled"`)
+
+	buf.Reset()
+	describeProjectToken(ctx, fake, projectAPI, false, pats[0].AccessToken)
+	assert.Contains(t, buf.String(), fmt.Sprintf("project_api_key is an access token of project %d", p.ID))
+	assert.NotContains(t, buf.String(), "project_name")
+}
+
+// TestDiagFromMissingTokenError checks that a request made without the token
+// a resource needs names the provider argument to set.
+func TestDiagFromMissingTokenError(t *testing.T) {
+	diags := diagFromErr(&client.MissingTokenError{
+		Method:   http.MethodPost,
+		Path:     "/api/1/notifications/{channel}/rules",
+		Resource: rollbarNotification,
+	})
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Missing Rollbar API token", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "provider argument project_api_key or environment variable ROLLBAR_PROJECT_API_KEY")
+
+	diags = diagFromErr(&client.MissingTokenError{Method: http.MethodGet, Path: "/api/1/teams", DataSource: rollbarTeam})
+	assert.Contains(t, diags[0].Detail, "provider argument api_key")
+}
+
+// TestValidateProjectTokens checks that each token in project_api_keys must
+// be an access token of the project it is keyed by.
+func TestValidateProjectTokens(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	var tokens []string
+	for _, name := range []string{"foo", "bar"} {
+		p, err := fake.CreateProject(ctx, name)
+		require.NoError(t, err)
+		pats, err := fake.ListProjectAccessTokens(ctx, p.ID)
+		require.NoError(t, err)
+		tokens = append(tokens, pats[0].AccessToken)
+	}
+	projects, err := fake.ListProjects(ctx)
+	require.NoError(t, err)
+	ids := map[string]int{}
+	for _, p := range projects {
+		ids[p.Name] = p.ID
+	}
+	apis := map[int]client.RollbarAPI{ids["foo"]: fake, ids["bar"]: fake, 999999: fake}
+
+	_, diags := validateTokens(ctx, tokenConfig{api: fake, token: "account", projectAPIs: apis, projectTokens: map[int]string{
+		ids["foo"]: tokens[0],
+		ids["bar"]: tokens[1],
+	}})
+	assert.Empty(t, diags)
+
+	_, diags = validateTokens(ctx, tokenConfig{api: fake, token: "account", projectAPIs: apis, projectTokens: map[int]string{
+		ids["foo"]: tokens[1],
+		999999:     tokens[0],
+	}})
+	assert.ElementsMatch(t, []string{
+		fmt.Sprintf(`error: Rollbar project_api_keys["%d"] belongs to another project`, ids["foo"]),
+		"error: Rollbar project 999999 not found",
+	}, summaries(diags))
+
+	// Without api_key, each token is only checked to be a project token
+	_, diags = validateTokens(ctx, tokenConfig{
+		api:           fake,
+		projectTokens: map[int]string{ids["foo"]: "typo"},
+		projectAPIs:   map[int]client.RollbarAPI{ids["foo"]: rejectedFake{projectOnlyFake{fake}}},
+	})
+	assert.Equal(t, []string{
+		"warning: Rollbar api_key not configured",
+		fmt.Sprintf(`error: Invalid Rollbar project_api_keys["%d"]`, ids["foo"]),
+	}, summaries(diags))
+}
+
+// TestDeriveProjectToken checks that an existing token with the required
+// scopes is reused, and that one is created if there is none.
+func TestDeriveProjectToken(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	p, err := fake.CreateProject(ctx, "foo")
+	require.NoError(t, err)
+
+	token, err := deriveProjectToken(ctx, fake, p.ID, []client.Scope{client.ScopeRead})
+	require.NoError(t, err)
+	pat, err := fake.ReadProjectAccessToken(ctx, p.ID, token)
+	require.NoError(t, err)
+	assert.Equal(t, "read", pat.Name)
+
+	token, err = deriveProjectToken(ctx, fake, p.ID, []client.Scope{client.ScopeRead, client.ScopeWrite})
+	require.NoError(t, err)
+	pat, err = fake.ReadProjectAccessToken(ctx, p.ID, token)
+	require.NoError(t, err)
+	assert.Equal(t, derivedTokenName, pat.Name)
+	assert.ElementsMatch(t, []client.Scope{client.ScopeRead, client.ScopeWrite}, pat.Scopes)
+
+	again, err := deriveProjectToken(ctx, fake, p.ID, []client.Scope{client.ScopeRead, client.ScopeWrite})
+	require.NoError(t, err)
+	assert.Equal(t, token, again)
+
+	_, err = deriveProjectToken(ctx, fake, 999999, []client.Scope{client.ScopeRead})
+	assert.ErrorIs(t, err, client.ErrNotFound)
+}
+
+// blockingFake is a Fake whose listing of a project's access tokens waits
+// for release, for the project with ID blocked.
+type blockingFake struct {
+	*clienttest.Fake
+	blocked int
+	release chan struct{}
+	calls   chan int
+}
+
+func (f blockingFake) ListProjectAccessTokens(ctx context.Context, projectID int) ([]client.ProjectAccessToken, error) {
+	f.calls <- projectID
+	if projectID == f.blocked {
+		<-f.release
+	}
+	return f.Fake.ListProjectAccessTokens(ctx, projectID)
+}
+
+// TestForProjectConcurrent checks that concurrent resources of a project
+// share one derived token, and that deriving it does not hold up other
+// projects.
+func TestForProjectConcurrent(t *testing.T) {
+	ctx := context.Background()
+	fake := clienttest.NewFake()
+	slow, err := fake.CreateProject(ctx, "slow")
+	require.NoError(t, err)
+	fast, err := fake.CreateProject(ctx, "fast")
+	require.NoError(t, err)
+	bf := blockingFake{Fake: fake, blocked: slow.ID, release: make(chan struct{}), calls: make(chan int, 10)}
+	meta := NewMeta(bf, bf)
+	meta.DeriveProjectTokens(func(string) client.RollbarAPI { return fake }, []client.Scope{client.ScopeRead})
+
+	const n = 5
+	results := make(chan *Meta, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			pm, err := meta.ForProject(ctx, slow.ID)
+			assert.NoError(t, err)
+			results <- pm
+		}()
+	}
+	require.Equal(t, slow.ID, <-bf.calls)
+
+	// The slow project's derivation is still waiting
+	pm, err := meta.ForProject(ctx, fast.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, pm)
+	assert.Equal(t, fast.ID, <-bf.calls)
+
+	close(bf.release)
+	first := <-results
+	require.NotNil(t, first)
+	for i := 1; i < n; i++ {
+		assert.Same(t, first, <-results)
+	}
+	assert.Empty(t, bf.calls, "the token of each project is derived once")
+}
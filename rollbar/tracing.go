@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package rollbar
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Standard OpenTelemetry environment variables consulted by the provider.  The
+// OTLP exporter itself reads OTEL_EXPORTER_OTLP_*, and the resource reads
+// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES.
+const (
+	envOtelSDKDisabled   = "OTEL_SDK_DISABLED"
+	envOtelTraceExporter = "OTEL_TRACES_EXPORTER"
+)
+
+// tracingServiceName is the default service.name of the provider's spans.
+const tracingServiceName = "terraform-provider-rollbar"
+
+// tracing holds the process wide tracer provider.  It is created the first
+// time a provider instance with tracing enabled is configured, and shared by
+// every provider instance after that.
+var tracing struct {
+	once sync.Once
+	tp   *sdktrace.TracerProvider
+	err  error
+}
+
+// tracingEnabledDefault is the DefaultFunc of schemaKeyTracingEnabled.
+// Tracing is on by default when OTEL_TRACES_EXPORTER selects the OTLP
+// exporter, unless OTEL_SDK_DISABLED is true.
+func tracingEnabledDefault() (interface{}, error) {
+	if strings.EqualFold(os.Getenv(envOtelSDKDisabled), "true") {
+		return false, nil
+	}
+	for _, e := range strings.Split(os.Getenv(envOtelTraceExporter), ",") {
+		if strings.TrimSpace(e) == "otlp" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// tracerProvider returns the process wide tracer provider, which exports
+// spans over OTLP/HTTP as configured by the standard OTEL_* environment
+// variables.
+func tracerProvider() (*sdktrace.TracerProvider, error) {
+	tracing.once.Do(func() {
+		ctx := context.Background()
+		exp, err := otlptracehttp.New(ctx)
+		if err != nil {
+			tracing.err = err
+			return
+		}
+		res, err := resource.New(ctx,
+			resource.WithAttributes(
+				semconv.ServiceName(tracingServiceName),
+				semconv.ServiceVersion(client.Version),
+			),
+			resource.WithFromEnv(),
+			resource.WithTelemetrySDK(),
+		)
+		if err != nil {
+			tracing.err = err
+			return
+		}
+		tracing.tp = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exp),
+			sdktrace.WithResource(res),
+		)
+	})
+	return tracing.tp, tracing.err
+}
+
+// ShutdownTracing flushes any buffered spans and stops the tracer provider.
+// It does nothing if tracing was never enabled.  Call it before the provider
+// process exits.
+func ShutdownTracing(ctx context.Context) error {
+	if tracing.tp == nil {
+		return nil
+	}
+	return tracing.tp.Shutdown(ctx)
+}
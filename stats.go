@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/rollbar/terraform-provider-rollbar/rollbar"
+	"github.com/rs/zerolog/log"
+)
+
+// envStatsFile is the path of a file to which a summary of the provider's API
+// calls is appended, as one JSON object per line, when the provider exits.
+const envStatsFile = "TERRAFORM_PROVIDER_ROLLBAR_STATS_FILE"
+
+// statsRecord is one line of the stats file.
+type statsRecord struct {
+	Time time.Time `json:"time"`
+	PID  int       `json:"pid"`
+	client.StatsSummary
+}
+
+// writeStats logs a summary of the API calls made by the provider and, if
+// envStatsFile is set, appends it to that file.  Nothing is written if the
+// provider made no API calls, as when Terraform only asks for its schema.
+func writeStats() {
+	sum := rollbar.APIStats().Summary()
+	if sum.Requests == 0 {
+		return
+	}
+	log.Info().
+		Int("requests", sum.Requests).
+		Int("retries", sum.Retries).
+		Int("errors", sum.Errors).
+		Float64("wait_seconds", sum.WaitSeconds).
+		Interface("endpoints", sum.Endpoints).
+		Msg("Rollbar API call summary")
+
+	path := os.Getenv(envStatsFile)
+	if path == "" {
+		return
+	}
+	err := appendStats(path, statsRecord{
+		Time:         time.Now().UTC(),
+		PID:          os.Getpid(),
+		StatsSummary: sum,
+	})
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("path", path).
+			Msg("Error writing Rollbar API call summary")
+	}
+}
+
+// appendStats appends rec to the file at path as a line of JSON.
+func appendStats(path string, rec statsRecord) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600) // #nosec
+	if err != nil {
+		return fmt.Errorf("error opening stats file: %w", err)
+	}
+	_, err = f.Write(append(b, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2024 Rollbar, Inc.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rollbar/terraform-provider-rollbar/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAppendStats checks that each summary is appended to the stats file as
+// its own line of JSON.
+func TestAppendStats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.jsonl")
+	rec := statsRecord{
+		Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		PID:  42,
+		StatsSummary: client.StatsSummary{
+			Requests: 3,
+			Retries:  1,
+			Endpoints: []client.EndpointStats{{
+				Method:   "GET",
+				Path:     "/api/1/user/{userID}/teams",
+				Requests: 3,
+				Retries:  1,
+				Statuses: map[int]int{200: 2, 500: 1},
+			}},
+		},
+	}
+	require.NoError(t, appendStats(path, rec))
+	require.NoError(t, appendStats(path, rec))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var lines []map[string]interface{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.Len(t, lines, 2)
+	assert.Equal(t, "2024-01-02T03:04:05Z", lines[0]["time"])
+	assert.Equal(t, float64(42), lines[0]["pid"])
+	assert.Equal(t, float64(3), lines[0]["requests"])
+	endpoints := lines[0]["endpoints"].([]interface{})
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, map[string]interface{}{"200": float64(2), "500": float64(1)},
+		endpoints[0].(map[string]interface{})["statuses"])
+}